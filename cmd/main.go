@@ -17,7 +17,7 @@ limitations under the License.
 package main
 
 import (
-	"log"
+	"os"
 
 	"sigs.k8s.io/kubebuilder/cmd/version"
 	"sigs.k8s.io/kubebuilder/pkg/cli"
@@ -31,19 +31,20 @@ func main() {
 			&pluginv2.Plugin{},
 			&pluginv3.Plugin{},
 		),
+		cli.WithExternalPlugins(),
+		cli.WithUserDefaults(),
 		cli.WithDefaultPlugins(
 			&pluginv2.Plugin{},
 		),
 		cli.WithExtraCommands(
-			newEditCmd(),
-			newCompletionCmd(),
 			version.NewCmd(),
 		),
 	)
 	if err != nil {
-		log.Fatal(err)
+		cli.PrintError(os.Stderr, err)
+		os.Exit(cli.ExitCode(err))
 	}
 	if err := c.Run(); err != nil {
-		log.Fatal(err)
+		os.Exit(cli.ExitCode(err))
 	}
 }
@@ -17,6 +17,12 @@ limitations under the License.
 package cmdutil
 
 import (
+	"fmt"
+
+	"github.com/spf13/afero"
+
+	"sigs.k8s.io/kubebuilder/pkg/model/file"
+	"sigs.k8s.io/kubebuilder/pkg/plugin"
 	"sigs.k8s.io/kubebuilder/pkg/plugin/scaffold"
 )
 
@@ -31,11 +37,17 @@ type RunOptions interface {
 	PostScaffold() error
 }
 
-// Run executes a command
-func Run(options RunOptions) error {
+// HasForce is implemented by the RunOptions that allow to overwrite existing files instead of failing
+type HasForce interface {
+	// GetForce returns the classes of files that are overwritten if they already exist
+	GetForce() []file.Class
+}
+
+// Run executes a command, writing files to the filesystem provided by the plugin context
+func Run(options RunOptions, ctx plugin.Context) error {
 	// Step 1: validate
 	if err := options.Validate(); err != nil {
-		return err
+		return validateError{err}
 	}
 
 	// Step 2: get scaffolder
@@ -45,13 +57,38 @@ func Run(options RunOptions) error {
 	}
 	// Step 3: scaffold
 	if scaffolder != nil {
+		base := ctx.Filesystem
+		if base == nil {
+			base = afero.NewOsFs()
+		}
+		// The changes are staged and committed once the whole scaffold succeeded,
+		// so that a failure does not leave the project half-scaffolded
+		staging := NewStagingFs(base)
+		fs := file.Filesystem{FS: staging, Report: ctx.Report, Templates: ctx.Templates}
+		if forcer, hasForce := options.(HasForce); hasForce {
+			fs.Force = forcer.GetForce()
+		}
+		scaffolder.InjectFS(fs)
+
 		if err := scaffolder.Scaffold(); err != nil {
 			return err
 		}
+		// The conflicts of every scaffold are reported at once
+		if ctx.Report != nil {
+			if conflicts := ctx.Report.Conflicts(); len(conflicts) != 0 {
+				return file.NewConflictsError(conflicts)
+			}
+		}
+		if err := staging.Commit(); err != nil {
+			return fmt.Errorf("unable to write the scaffold, the project was restored: %w", err)
+		}
+	}
+	// Step 4: finish, which may have side effects so it is skipped in dry-run mode
+	if ctx.DryRun {
+		return nil
 	}
-	// Step 4: finish
 	if err := options.PostScaffold(); err != nil {
-		return err
+		return postScaffoldError{err}
 	}
 
 	return nil
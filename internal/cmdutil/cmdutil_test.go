@@ -0,0 +1,121 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmdutil
+
+import (
+	"errors"
+	"io/ioutil"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/afero"
+
+	"sigs.k8s.io/kubebuilder/pkg/model/file"
+	"sigs.k8s.io/kubebuilder/pkg/plugin"
+	"sigs.k8s.io/kubebuilder/pkg/plugin/scaffold"
+)
+
+var _ = Describe("Run", func() {
+	var (
+		fs  afero.Fs
+		ctx plugin.Context
+	)
+
+	BeforeEach(func() {
+		fs = afero.NewMemMapFs()
+		ctx = plugin.Context{Filesystem: fs, Report: &file.Report{}, Stdout: ioutil.Discard}
+	})
+
+	It("should write the scaffold before the post-scaffold step", func() {
+		options := &fakeOptions{scaffolder: &fakeScaffolder{}}
+		options.postScaffold = func() error {
+			Expect(afero.Exists(fs, "scaffolded")).To(BeTrue())
+			return nil
+		}
+
+		Expect(Run(options, ctx)).To(Succeed())
+		Expect(afero.Exists(fs, "scaffolded")).To(BeTrue())
+	})
+
+	It("should not write anything if the scaffold fails", func() {
+		scaffoldErr := errors.New("scaffold error")
+		options := &fakeOptions{scaffolder: &fakeScaffolder{err: scaffoldErr}}
+
+		Expect(Run(options, ctx)).To(MatchError(scaffoldErr))
+		Expect(afero.Exists(fs, "scaffolded")).To(BeFalse())
+		Expect(options.postScaffolded).To(BeFalse())
+	})
+
+	It("should not write anything if the scaffold found conflicts", func() {
+		conflict := file.Conflict{Path: "existing", Class: file.OtherClass}
+		options := &fakeOptions{scaffolder: &fakeScaffolder{conflicts: []file.Conflict{conflict}}}
+
+		err := Run(options, ctx)
+		Expect(file.IsConflictsError(err)).To(BeTrue())
+		Expect(err.Error()).To(ContainSubstring("existing (other)"))
+		Expect(afero.Exists(fs, "scaffolded")).To(BeFalse())
+	})
+
+	It("should let the scaffolder overwrite the forced classes", func() {
+		scaffolder := &fakeScaffolder{}
+		options := &forcedOptions{fakeOptions{scaffolder: scaffolder}}
+
+		Expect(Run(options, ctx)).To(Succeed())
+		Expect(scaffolder.fs.Force).To(Equal([]file.Class{file.SamplesClass}))
+	})
+})
+
+type fakeOptions struct {
+	scaffolder     scaffold.Scaffolder
+	postScaffold   func() error
+	postScaffolded bool
+}
+
+func (o *fakeOptions) Validate() error                             { return nil }
+func (o *fakeOptions) GetScaffolder() (scaffold.Scaffolder, error) { return o.scaffolder, nil }
+func (o *fakeOptions) PostScaffold() error {
+	o.postScaffolded = true
+	if o.postScaffold != nil {
+		return o.postScaffold()
+	}
+	return nil
+}
+
+// forcedOptions force the samples class
+type forcedOptions struct {
+	fakeOptions
+}
+
+func (o *forcedOptions) GetForce() []file.Class { return []file.Class{file.SamplesClass} }
+
+// fakeScaffolder writes a file, reports the conflicts and then fails with err, if any
+type fakeScaffolder struct {
+	fs        file.Filesystem
+	conflicts []file.Conflict
+	err       error
+}
+
+func (s *fakeScaffolder) InjectFS(fs file.Filesystem) { s.fs = fs }
+func (s *fakeScaffolder) Scaffold() error {
+	if err := afero.WriteFile(s.fs.FS, "scaffolded", nil, 0644); err != nil {
+		return err
+	}
+	for _, conflict := range s.conflicts {
+		s.fs.Report.AddConflict(conflict)
+	}
+	return s.err
+}
@@ -0,0 +1,54 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmdutil
+
+import (
+	"errors"
+)
+
+// This file contains the errors returned by Run to tell which step failed
+// Exported functions are provided to check which kind of error was returned
+
+// validateError is a wrapper error that will be used for errors returned by RunOptions.Validate
+type validateError struct {
+	error
+}
+
+// Unwrap implements Wrapper interface
+func (e validateError) Unwrap() error {
+	return e.error
+}
+
+// IsValidateError checks if the error was returned by RunOptions.Validate
+func IsValidateError(err error) bool {
+	return errors.As(err, &validateError{})
+}
+
+// postScaffoldError is a wrapper error that will be used for errors returned by RunOptions.PostScaffold
+type postScaffoldError struct {
+	error
+}
+
+// Unwrap implements Wrapper interface
+func (e postScaffoldError) Unwrap() error {
+	return e.error
+}
+
+// IsPostScaffoldError checks if the error was returned by RunOptions.PostScaffold
+func IsPostScaffoldError(err error) bool {
+	return errors.As(err, &postScaffoldError{})
+}
@@ -0,0 +1,219 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmdutil
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/spf13/afero"
+)
+
+var _ afero.Fs = &StagingFs{}
+
+// StagingFs stages the changes to a base file system so that they can be committed at once or discarded.
+// Reads fall through to the base file system while writes are kept in memory, and removed files are hidden
+// instead of being removed. Removed files are not hidden from directory listings.
+type StagingFs struct {
+	afero.Fs
+
+	base  afero.Fs
+	layer afero.Fs
+
+	mu sync.Mutex
+	// written and removed are the staged changes, by path
+	written map[string]bool
+	removed map[string]bool
+}
+
+// NewStagingFs returns a StagingFs over base.
+func NewStagingFs(base afero.Fs) *StagingFs {
+	layer := afero.NewMemMapFs()
+	return &StagingFs{
+		Fs:      afero.NewCopyOnWriteFs(afero.NewReadOnlyFs(base), layer),
+		base:    base,
+		layer:   layer,
+		written: make(map[string]bool),
+		removed: make(map[string]bool),
+	}
+}
+
+// isRemoved returns true if name was removed and not written again.
+func (fs *StagingFs) isRemoved(name string) bool {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	return fs.removed[filepath.Clean(name)]
+}
+
+// stage records that name was written or removed.
+func (fs *StagingFs) stage(name string, removed bool) {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	name = filepath.Clean(name)
+	fs.written[name] = !removed
+	fs.removed[name] = removed
+	if removed {
+		delete(fs.written, name)
+	} else {
+		delete(fs.removed, name)
+	}
+}
+
+// Create implements afero.Fs
+func (fs *StagingFs) Create(name string) (afero.File, error) {
+	f, err := fs.Fs.Create(name)
+	if err == nil {
+		fs.stage(name, false)
+	}
+	return f, err
+}
+
+// Open implements afero.Fs
+func (fs *StagingFs) Open(name string) (afero.File, error) {
+	if fs.isRemoved(name) {
+		return nil, &os.PathError{Op: "open", Path: name, Err: os.ErrNotExist}
+	}
+	return fs.Fs.Open(name)
+}
+
+// OpenFile implements afero.Fs
+func (fs *StagingFs) OpenFile(name string, flag int, perm os.FileMode) (afero.File, error) {
+	if fs.isRemoved(name) {
+		if flag&os.O_CREATE == 0 {
+			return nil, &os.PathError{Op: "open", Path: name, Err: os.ErrNotExist}
+		}
+		// The removed file is truncated, as it would have been recreated
+		flag |= os.O_TRUNC
+	}
+	f, err := fs.Fs.OpenFile(name, flag, perm)
+	if err == nil && flag&(os.O_WRONLY|os.O_RDWR|os.O_APPEND|os.O_CREATE|os.O_TRUNC) != 0 {
+		fs.stage(name, false)
+	}
+	return f, err
+}
+
+// Remove implements afero.Fs
+func (fs *StagingFs) Remove(name string) error {
+	if _, err := fs.Stat(name); err != nil {
+		return &os.PathError{Op: "remove", Path: name, Err: os.ErrNotExist}
+	}
+	// Only the in-memory copy of a written file can be removed, base files are hidden instead
+	_ = fs.Fs.Remove(name)
+	fs.stage(name, true)
+	return nil
+}
+
+// Stat implements afero.Fs
+func (fs *StagingFs) Stat(name string) (os.FileInfo, error) {
+	if fs.isRemoved(name) {
+		return nil, &os.PathError{Op: "stat", Path: name, Err: os.ErrNotExist}
+	}
+	return fs.Fs.Stat(name)
+}
+
+// Name implements afero.Fs
+func (fs *StagingFs) Name() string {
+	return "StagingFs"
+}
+
+// Commit writes the staged files to the base file system and removes the staged removals from it.
+// If it fails, the files of the base file system that were already changed are restored.
+func (fs *StagingFs) Commit() (err error) {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+
+	var restores []func() error
+	defer func() {
+		if err != nil {
+			// Restore in reverse order, the error of the commit is more relevant than the restore ones
+			for i := len(restores) - 1; i >= 0; i-- {
+				_ = restores[i]()
+			}
+		}
+	}()
+
+	for _, path := range sortedKeys(fs.written) {
+		restore, err := backup(fs.base, path)
+		if err != nil {
+			return err
+		}
+		restores = append(restores, restore)
+		if err := copyFile(fs.layer, fs.base, path); err != nil {
+			return err
+		}
+	}
+	for _, path := range sortedKeys(fs.removed) {
+		restore, err := backup(fs.base, path)
+		if err != nil {
+			return err
+		}
+		restores = append(restores, restore)
+		if err := fs.base.Remove(path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+
+	fs.written = make(map[string]bool)
+	fs.removed = make(map[string]bool)
+	return nil
+}
+
+// backup returns a function that restores path in fs to its current state.
+func backup(fs afero.Fs, path string) (func() error, error) {
+	info, err := fs.Stat(path)
+	if os.IsNotExist(err) {
+		return func() error { return fs.Remove(path) }, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := afero.ReadFile(fs, path)
+	if err != nil {
+		return nil, err
+	}
+	return func() error { return afero.WriteFile(fs, path, content, info.Mode()) }, nil
+}
+
+// copyFile copies path from src to dst, creating its directory if needed.
+func copyFile(src, dst afero.Fs, path string) error {
+	info, err := src.Stat(path)
+	if err != nil {
+		return err
+	}
+	content, err := afero.ReadFile(src, path)
+	if err != nil {
+		return err
+	}
+
+	if err := dst.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return afero.WriteFile(dst, path, content, info.Mode())
+}
+
+// sortedKeys returns the keys of m in order.
+func sortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,104 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmdutil
+
+import (
+	"errors"
+	"os"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/afero"
+)
+
+var _ = Describe("StagingFs", func() {
+	var (
+		base afero.Fs
+		fs   *StagingFs
+	)
+
+	BeforeEach(func() {
+		base = afero.NewMemMapFs()
+		Expect(afero.WriteFile(base, "existing", []byte("original"), 0600)).To(Succeed())
+		Expect(afero.WriteFile(base, "removed", []byte("original"), 0644)).To(Succeed())
+		fs = NewStagingFs(base)
+	})
+
+	It("should stage the changes until they are committed", func() {
+		Expect(afero.WriteFile(fs, "existing", []byte("updated"), 0600)).To(Succeed())
+		Expect(fs.MkdirAll("dir", 0755)).To(Succeed())
+		Expect(afero.WriteFile(fs, "dir/created", []byte("created"), 0644)).To(Succeed())
+		Expect(fs.Remove("removed")).To(Succeed())
+
+		Expect(afero.ReadFile(fs, "existing")).To(Equal([]byte("updated")))
+		Expect(afero.Exists(fs, "removed")).To(BeFalse())
+		Expect(afero.ReadFile(base, "existing")).To(Equal([]byte("original")))
+		Expect(afero.Exists(base, "dir/created")).To(BeFalse())
+		Expect(afero.Exists(base, "removed")).To(BeTrue())
+
+		Expect(fs.Commit()).To(Succeed())
+		Expect(afero.ReadFile(base, "existing")).To(Equal([]byte("updated")))
+		Expect(afero.ReadFile(base, "dir/created")).To(Equal([]byte("created")))
+		Expect(afero.Exists(base, "removed")).To(BeFalse())
+		info, err := base.Stat("existing")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(info.Mode().Perm()).To(Equal(os.FileMode(0600)))
+	})
+
+	It("should fail to remove a file that does not exist", func() {
+		Expect(fs.Remove("removed")).To(Succeed())
+		Expect(fs.Remove("removed")).NotTo(Succeed())
+		Expect(fs.Remove("missing")).NotTo(Succeed())
+	})
+
+	It("should write again a removed file", func() {
+		Expect(fs.Remove("existing")).To(Succeed())
+		Expect(afero.WriteFile(fs, "existing", []byte("recreated"), 0600)).To(Succeed())
+
+		Expect(fs.Commit()).To(Succeed())
+		Expect(afero.ReadFile(base, "existing")).To(Equal([]byte("recreated")))
+	})
+
+	It("should restore the base file system if the commit fails", func() {
+		fs = NewStagingFs(failingFs{Fs: base, path: "removed"})
+		Expect(afero.WriteFile(fs, "existing", []byte("updated"), 0600)).To(Succeed())
+		Expect(afero.WriteFile(fs, "created", []byte("created"), 0644)).To(Succeed())
+		Expect(fs.Remove("removed")).To(Succeed())
+
+		Expect(fs.Commit()).To(MatchError(errFailingFs))
+		Expect(afero.ReadFile(base, "existing")).To(Equal([]byte("original")))
+		Expect(afero.Exists(base, "created")).To(BeFalse())
+		Expect(afero.ReadFile(base, "removed")).To(Equal([]byte("original")))
+	})
+})
+
+var errFailingFs = errors.New("failing fs")
+
+// failingFs fails to remove path
+type failingFs struct {
+	afero.Fs
+
+	path string
+}
+
+// Remove implements afero.Fs
+func (fs failingFs) Remove(name string) error {
+	if name == fs.path {
+		return errFailingFs
+	}
+	return fs.Fs.Remove(name)
+}
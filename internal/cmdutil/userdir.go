@@ -0,0 +1,41 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmdutil
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// UserConfigDir returns the directory holding the user configuration of kubebuilder,
+// $XDG_CONFIG_HOME/kubebuilder, defaulting to $HOME/.config/kubebuilder. The variables are
+// looked up with getenv, the home directory of the OS is used if $HOME is not set.
+func UserConfigDir(getenv func(string) string) (string, error) {
+	configDir := getenv("XDG_CONFIG_HOME")
+	if configDir == "" {
+		home := getenv("HOME")
+		if home == "" {
+			var err error
+			if home, err = os.UserHomeDir(); err != nil {
+				return "", fmt.Errorf("unable to find the user configuration directory: %v", err)
+			}
+		}
+		configDir = filepath.Join(home, ".config")
+	}
+	return filepath.Join(configDir, "kubebuilder"), nil
+}
@@ -17,9 +17,12 @@ limitations under the License.
 package config
 
 import (
+	"bytes"
+	"crypto/sha256"
 	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
 
 	"github.com/spf13/afero"
 
@@ -50,12 +53,22 @@ func exists(fs afero.Fs, path string) (bool, error) {
 	return false, err
 }
 
+// ErrModified is returned when the configuration file was modified since the configuration was loaded
+var ErrModified = errors.New("the configuration file was modified by another command since it was loaded")
+
 func readFrom(fs afero.Fs, path string) (c config.Config, err error) {
+	c, _, err = readChecksumFrom(fs, path)
+	return
+}
+
+// readChecksumFrom reads the configuration at path in fs along with the checksum of the file
+func readChecksumFrom(fs afero.Fs, path string) (c config.Config, checksum []byte, err error) {
 	// Read the file
 	in, err := afero.ReadFile(fs, path) //nolint:gosec
 	if err != nil {
 		return
 	}
+	checksum = sum(in)
 
 	// Unmarshal the file content
 	if err = c.Unmarshal(in); err != nil {
@@ -77,7 +90,12 @@ func Read() (*config.Config, error) {
 
 // ReadFrom obtains the configuration from the provided path but doesn't allow to persist changes
 func ReadFrom(path string) (*config.Config, error) {
-	c, err := readFrom(afero.NewOsFs(), path)
+	return ReadFromFs(afero.NewOsFs(), path)
+}
+
+// ReadFromFs obtains the configuration from the provided path in fs but doesn't allow to persist changes
+func ReadFromFs(fs afero.Fs, path string) (*config.Config, error) {
+	c, err := readFrom(fs, path)
 	return &c, err
 }
 
@@ -91,19 +109,26 @@ type Config struct {
 	path string
 	// mustNotExist requires the file not to exist when saving it
 	mustNotExist bool
-	// fs is for testing.
+	// fs is the file system the config is saved to
 	fs afero.Fs
+	// checksum is the checksum of the file when it was loaded or last saved
+	checksum []byte
 }
 
 // New creates a new configuration that will be stored at the provided path
 func New(path string) *Config {
+	return NewFs(afero.NewOsFs(), path)
+}
+
+// NewFs creates a new configuration that will be stored at the provided path in fs
+func NewFs(fs afero.Fs, path string) *Config {
 	return &Config{
 		Config: config.Config{
 			Version: DefaultVersion,
 		},
 		path:         path,
 		mustNotExist: true,
-		fs:           afero.NewOsFs(),
+		fs:           fs,
 	}
 }
 
@@ -115,7 +140,13 @@ func Load() (*Config, error) {
 // LoadInitialized calls Load() but returns helpful error messages if the config
 // does not exist.
 func LoadInitialized() (*Config, error) {
-	c, err := Load()
+	return LoadInitializedFs(afero.NewOsFs())
+}
+
+// LoadInitializedFs obtains the configuration from the default path in fs allowing to persist
+// changes, returning helpful error messages if the config does not exist.
+func LoadInitializedFs(fs afero.Fs) (*Config, error) {
+	c, err := LoadFromFs(fs, DefaultPath)
 	if os.IsNotExist(err) {
 		return nil, errors.New("unable to find configuration file, project must be initialized")
 	}
@@ -124,13 +155,40 @@ func LoadInitialized() (*Config, error) {
 
 // LoadFrom obtains the configuration from the provided path allowing to persist changes (Save method)
 func LoadFrom(path string) (*Config, error) {
-	fs := afero.NewOsFs()
-	c, err := readFrom(fs, path)
-	return &Config{Config: c, path: path, fs: fs}, err
+	return LoadFromFs(afero.NewOsFs(), path)
+}
+
+// LoadFromFs obtains the configuration from the provided path in fs allowing to persist changes (Save method)
+func LoadFromFs(fs afero.Fs, path string) (*Config, error) {
+	c, checksum, err := readChecksumFrom(fs, path)
+	return &Config{Config: c, path: path, fs: fs, checksum: checksum}, err
+}
+
+// CheckUnmodified returns ErrModified if the configuration file was modified since it was loaded or saved
+func (c Config) CheckUnmodified() error {
+	if c.checksum == nil {
+		return nil
+	}
+	if c.fs == nil {
+		c.fs = afero.NewOsFs()
+	}
+
+	in, err := afero.ReadFile(c.fs, c.path)
+	if os.IsNotExist(err) {
+		return ErrModified
+	}
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(sum(in), c.checksum) {
+		return ErrModified
+	}
+	return nil
 }
 
 // Save saves the configuration information
-func (c Config) Save() error {
+// The file is replaced atomically, and it is not saved if it was modified since it was loaded
+func (c *Config) Save() error {
 	if c.fs == nil {
 		c.fs = afero.NewOsFs()
 	}
@@ -150,6 +208,8 @@ func (c Config) Save() error {
 		if alreadyExists {
 			return saveError{errors.New("configuration already exists in the provided path")}
 		}
+	} else if err := c.CheckUnmodified(); err != nil {
+		return saveError{err}
 	}
 
 	// Marshall into YAML
@@ -159,14 +219,53 @@ func (c Config) Save() error {
 	}
 
 	// Write the marshalled configuration
-	err = afero.WriteFile(c.fs, c.path, content, 0600)
+	err = writeFile(c.fs, c.path, content, 0600)
 	if err != nil {
 		return saveError{fmt.Errorf("failed to save configuration to %s: %v", c.path, err)}
 	}
 
+	c.checksum = sum(content)
+	c.mustNotExist = false
 	return nil
 }
 
+// writeFile writes content to path in fs atomically: it is written to a temporary file in the same
+// directory which is then renamed to path, so readers never find a partially written file
+// perm is only used if the file does not exist yet
+func writeFile(fs afero.Fs, path string, content []byte, perm os.FileMode) (err error) {
+	tmp, err := afero.TempFile(fs, filepath.Dir(path), "."+filepath.Base(path)+"-")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_ = fs.Remove(tmp.Name())
+		}
+	}()
+
+	if _, err = tmp.Write(content); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+	// Keep the permissions of the replaced file
+	if info, statErr := fs.Stat(path); statErr == nil {
+		perm = info.Mode().Perm()
+	}
+	if err = fs.Chmod(tmp.Name(), perm); err != nil {
+		return err
+	}
+	return fs.Rename(tmp.Name(), path)
+}
+
+// sum returns the checksum of the file content b
+func sum(b []byte) []byte {
+	checksum := sha256.Sum256(b)
+	return checksum[:]
+}
+
 // Path returns the path for configuration file
 func (c Config) Path() string {
 	return c.path
@@ -179,3 +278,8 @@ type saveError struct {
 func (e saveError) Error() string {
 	return fmt.Sprintf("unable to save the configuration: %v", e.err)
 }
+
+// Unwrap implements Wrapper interface
+func (e saveError) Unwrap() error {
+	return e.err
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package config
 
 import (
+	"errors"
 	"os"
 
 	. "github.com/onsi/ginkgo"
@@ -205,4 +206,52 @@ plugins:
 			Expect(err).To(HaveOccurred())
 		})
 	})
+
+	Context("when saving a loaded config", func() {
+		var fs afero.Fs
+
+		BeforeEach(func() {
+			fs = afero.NewMemMapFs()
+			Expect(afero.WriteFile(fs, DefaultPath, []byte("version: \"2\"\n"), 0644)).To(Succeed())
+		})
+
+		It("should replace the file keeping its permissions", func() {
+			c, err := LoadFromFs(fs, DefaultPath)
+			Expect(err).NotTo(HaveOccurred())
+			c.Domain = "example.com"
+			Expect(c.Save()).To(Succeed())
+
+			cfgBytes, err := afero.ReadFile(fs, DefaultPath)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(cfgBytes)).To(Equal("domain: example.com\nversion: \"2\"\n"))
+			info, err := fs.Stat(DefaultPath)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(info.Mode().Perm()).To(Equal(os.FileMode(0644)))
+
+			By("not leaving temporary files behind")
+			files, err := afero.ReadDir(fs, ".")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(files).To(HaveLen(1))
+
+			By("saving it again")
+			c.Repo = "github.com/example/project"
+			Expect(c.Save()).To(Succeed())
+		})
+
+		It("should fail if the file was modified since it was loaded", func() {
+			c, err := LoadFromFs(fs, DefaultPath)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(c.CheckUnmodified()).To(Succeed())
+
+			Expect(afero.WriteFile(fs, DefaultPath, []byte("version: \"3-alpha\"\n"), 0644)).To(Succeed())
+			Expect(c.CheckUnmodified()).To(MatchError(ErrModified))
+			err = c.Save()
+			Expect(err).To(HaveOccurred())
+			Expect(errors.Is(err, ErrModified)).To(BeTrue())
+
+			cfgBytes, err := afero.ReadFile(fs, DefaultPath)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(cfgBytes)).To(Equal("version: \"3-alpha\"\n"))
+		})
+	})
 })
@@ -0,0 +1,116 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/spf13/afero"
+)
+
+// lockSuffix is appended to the path of the configuration file to get the path of the project lock
+const lockSuffix = ".lock"
+
+// lockPollInterval is how often a held lock is checked while waiting for it
+const lockPollInterval = 100 * time.Millisecond
+
+// Lock is an advisory lock on a project, held by a command while it modifies the project files so that
+// concurrent commands, e.g. run by `make -j`, do not interleave their changes
+// The lock is a file next to the configuration file, created exclusively, so it is left behind if the
+// command holding it is killed
+type Lock struct {
+	fs   afero.Fs
+	path string
+}
+
+// LockFs takes the lock of the project whose configuration file is at path in fs, waiting up to timeout
+// for another command holding it to release it
+func LockFs(fs afero.Fs, path string, timeout time.Duration) (*Lock, error) {
+	l := &Lock{fs: fs, path: path + lockSuffix}
+
+	deadline := time.Now().Add(timeout)
+	for {
+		// O_EXCL makes taking the lock atomic, the existence check is only needed for
+		// file systems which ignore it, like afero's in-memory one
+		held, err := exists(fs, l.path)
+		if err != nil {
+			return nil, fmt.Errorf("unable to lock the project: %w", err)
+		}
+		if !held {
+			var f afero.File
+			f, err = fs.OpenFile(l.path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+			if err == nil {
+				// The process id is only informative, a write error does not invalidate the lock
+				_, _ = f.WriteString(strconv.Itoa(os.Getpid()))
+				if err := f.Close(); err != nil {
+					return nil, err
+				}
+				return l, nil
+			}
+			if !os.IsExist(err) {
+				return nil, fmt.Errorf("unable to lock the project: %w", err)
+			}
+		}
+		if time.Now().After(deadline) {
+			return nil, LockedError{Path: l.path, Holder: l.holder()}
+		}
+		time.Sleep(lockPollInterval)
+	}
+}
+
+// holder returns the process id written to the lock file, if it can be read
+func (l Lock) holder() string {
+	b, err := afero.ReadFile(l.fs, l.path)
+	if err != nil {
+		return ""
+	}
+	return string(b)
+}
+
+// Unlock releases the lock
+func (l Lock) Unlock() error {
+	if err := l.fs.Remove(l.path); err != nil {
+		return fmt.Errorf("unable to unlock the project: %w", err)
+	}
+	return nil
+}
+
+// LockedError is returned when the lock of a project is held by another command
+type LockedError struct {
+	// Path is the lock file
+	Path string
+	// Holder is the process id of the command holding the lock, if known
+	Holder string
+}
+
+// Error implements error interface
+func (e LockedError) Error() string {
+	holder := ""
+	if e.Holder != "" {
+		holder = fmt.Sprintf(" (process %s)", e.Holder)
+	}
+	return fmt.Sprintf("the project is locked by another command%s, "+
+		"if no other command is running remove %s", holder, e.Path)
+}
+
+// Unwrap implements Wrapper interface, a held lock means that the lock file already exists
+func (e LockedError) Unwrap() error {
+	return os.ErrExist
+}
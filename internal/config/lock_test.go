@@ -0,0 +1,65 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"errors"
+	"os"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/afero"
+)
+
+var _ = Describe("Lock", func() {
+	var fs afero.Fs
+
+	BeforeEach(func() {
+		fs = afero.NewMemMapFs()
+	})
+
+	It("should be held by a single command at a time", func() {
+		lock, err := LockFs(fs, DefaultPath, 0)
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = LockFs(fs, DefaultPath, 0)
+		Expect(err).To(HaveOccurred())
+		Expect(err).To(BeAssignableToTypeOf(LockedError{}))
+		Expect(errors.Is(err, os.ErrExist)).To(BeTrue())
+		Expect(err.Error()).To(ContainSubstring("remove PROJECT.lock"))
+
+		Expect(lock.Unlock()).To(Succeed())
+		lock, err = LockFs(fs, DefaultPath, 0)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(lock.Unlock()).To(Succeed())
+	})
+
+	It("should wait for the command holding it", func() {
+		lock, err := LockFs(fs, DefaultPath, 0)
+		Expect(err).NotTo(HaveOccurred())
+		go func() {
+			defer GinkgoRecover()
+			time.Sleep(2 * lockPollInterval)
+			Expect(lock.Unlock()).To(Succeed())
+		}()
+
+		lock, err = LockFs(fs, DefaultPath, time.Minute)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(lock.Unlock()).To(Succeed())
+	})
+})
@@ -0,0 +1,153 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+
+	internalconfig "sigs.k8s.io/kubebuilder/internal/config"
+	"sigs.k8s.io/kubebuilder/pkg/model/config"
+	"sigs.k8s.io/kubebuilder/pkg/plugin"
+)
+
+// validationReport is printed with --output when a project config is valid, the errors of
+// invalid ones are printed in the error report.
+type validationReport struct {
+	// Path is the validated file.
+	Path string `json:"path"`
+	// Valid is true if the file has no errors.
+	Valid bool `json:"valid"`
+}
+
+func (c cli) newAlphaConfigCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "Validate the project config",
+		Long: `Validate the project config against its JSON Schema.
+
+The schema includes the schemas registered by the plugins for their section of the 'plugins' field.
+`,
+	}
+	cmd.AddCommand(c.newConfigValidateCmd(), c.newConfigSchemaCmd())
+	return cmd
+}
+
+func (c cli) newConfigValidateCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "validate [path]",
+		Short: "Validate a project config and report the errors of each field",
+		Example: fmt.Sprintf(`  # Validate the PROJECT file of the current directory
+  %[1]s alpha config validate
+
+  # Validate a project config and print the errors as JSON
+  %[1]s alpha config validate path/to/PROJECT --output json`, c.commandName),
+		Args: func(cmd *cobra.Command, args []string) error {
+			return newError(UsageError, cobra.MaximumNArgs(1)(cmd, args))
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path := internalconfig.DefaultPath
+			if len(args) == 1 {
+				path = args[0]
+			}
+			b, err := afero.ReadFile(c.fs, path)
+			if err != nil {
+				if os.IsNotExist(err) {
+					return newError(UsageError, fmt.Errorf("unable to find %s", path))
+				}
+				return newError(InternalError, err)
+			}
+
+			schema, err := c.newConfigSchema()
+			if err != nil {
+				return newError(PluginError, err)
+			}
+			errs, err := schema.Validate(b)
+			if err != nil {
+				return newError(UsageError, fmt.Errorf("unable to parse %s: %v", path, err))
+			}
+
+			if len(errs) == 0 {
+				if c.output != "" {
+					return printOutput(cmd.OutOrStdout(), c.output, validationReport{Path: path, Valid: true})
+				}
+				fmt.Fprintf(cmd.OutOrStdout(), "%s is valid.\n", path)
+				return nil
+			}
+			// With --output the invalid fields are printed as part of the error.
+			if c.output == "" {
+				for _, fieldErr := range errs {
+					fmt.Fprintf(cmd.OutOrStdout(), "%s: %v\n", path, fieldErr)
+				}
+			}
+			// The errors were already reported, usage would only hide them.
+			cmd.SilenceUsage = true
+			return newError(UsageError, invalidConfigError{path: path, errs: errs})
+		},
+	}
+}
+
+func (c cli) newConfigSchemaCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "schema",
+		Short: "Print the JSON Schema of the project config",
+		Long: `Print the JSON Schema of the project config, including the schemas of the plugin configs.
+
+Editors which support JSON Schema can use it to complete and validate PROJECT files.
+`,
+		Example: fmt.Sprintf(`  # Write the schema to a file
+  %[1]s alpha config schema > project.schema.json`, c.commandName),
+		Args: func(cmd *cobra.Command, args []string) error {
+			return newError(UsageError, cobra.NoArgs(cmd, args))
+		},
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			schema, err := c.newConfigSchema()
+			if err != nil {
+				return newError(PluginError, err)
+			}
+			output := c.output
+			if output == "" {
+				output = outputJSON
+			}
+			return printOutput(cmd.OutOrStdout(), output, schema)
+		},
+	}
+}
+
+// newConfigSchema returns the schema of the project config with the schemas of the plugin configs.
+func (c cli) newConfigSchema() (*config.Schema, error) {
+	plugins := make(map[string]*config.Schema)
+	for _, p := range c.getAllPlugins() {
+		getter, isGetter := p.(plugin.ConfigSchemaGetter)
+		if !isGetter {
+			continue
+		}
+		v := getter.GetConfigSchema()
+		if v == nil {
+			continue
+		}
+		schema, err := config.SchemaFor(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid config schema of plugin %q: %v", plugin.KeyFor(p), err)
+		}
+		plugins[plugin.KeyFor(p)] = schema
+	}
+	return config.NewSchema(plugins)
+}
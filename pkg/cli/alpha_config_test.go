@@ -0,0 +1,116 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/spf13/afero"
+
+	"sigs.k8s.io/kubebuilder/pkg/model/config"
+	"sigs.k8s.io/kubebuilder/pkg/plugin"
+)
+
+var _ plugin.ConfigSchemaGetter = mockConfigSchemaPlugin{}
+
+type mockConfigSchemaPlugin struct {
+	mockPlugin
+}
+
+type mockPluginConfig struct {
+	Replicas int `json:"replicas"`
+}
+
+// GetConfigSchema will return the struct the plugin config is decoded into
+func (mockConfigSchemaPlugin) GetConfigSchema() interface{} { return mockPluginConfig{} }
+
+var _ = Describe("alpha config", func() {
+	var (
+		pluginKey = "go.example.com/v1"
+		p         = mockConfigSchemaPlugin{makeBasePlugin("go.example.com", "v1", config.Version3Alpha).(mockPlugin)}
+
+		fs             afero.Fs
+		stdout, stderr bytes.Buffer
+	)
+
+	BeforeEach(func() {
+		fs = afero.NewMemMapFs()
+		stdout.Reset()
+		stderr.Reset()
+	})
+
+	run := func(project string, args ...string) error {
+		Expect(afero.WriteFile(fs, "PROJECT", []byte(project), 0644)).To(Succeed())
+		c, err := New(
+			WithPlugins(p),
+			WithDefaultPlugins(p),
+			WithArgs(append([]string{"alpha", "config"}, args...)...),
+			WithIOStreams(&bytes.Buffer{}, &stdout, &stderr),
+			WithFilesystem(fs),
+		)
+		Expect(err).NotTo(HaveOccurred())
+		return c.Run()
+	}
+
+	It("should validate the plugin configs against the registered schemas", func() {
+		project := `version: 3-alpha
+layout: go.example.com/v1
+plugins:
+  go.example.com/v1:
+    replicas: 2
+`
+		Expect(run(project, "validate")).To(Succeed())
+		Expect(stdout.String()).To(Equal("PROJECT is valid.\n"))
+	})
+
+	It("should report the invalid fields", func() {
+		project := `version: 3-alpha
+layout: go.example.com/v1
+plugins:
+  go.example.com/v1:
+    replicas: two
+`
+		err := run(project, "validate")
+		Expect(ExitCode(err)).To(Equal(2))
+		Expect(stdout.String()).To(Equal(
+			"PROJECT: plugins[\"go.example.com/v1\"].replicas: must be of type integer, found string\n"))
+		Expect(stderr.String()).To(Equal("Error: PROJECT has 1 invalid fields\n"))
+
+		stdout.Reset()
+		Expect(run(project, "validate", "--output", "json")).NotTo(Succeed())
+		Expect(stdout.String()).To(MatchJSON(`{"error": {
+			"kind": "usage",
+			"message": "PROJECT has 1 invalid fields",
+			"exitCode": 2,
+			"fields": [{
+				"field": "plugins[\"go.example.com/v1\"].replicas",
+				"message": "must be of type integer, found string"
+			}]
+		}}`))
+	})
+
+	It("should print the schema with the registered plugin schemas", func() {
+		Expect(run("version: 3-alpha\nlayout: "+pluginKey+"\n", "schema")).To(Succeed())
+		var s config.Schema
+		Expect(json.Unmarshal(stdout.Bytes(), &s)).To(Succeed())
+		Expect(config.SchemaFor(mockPluginConfig{})).To(Equal(s.Properties["plugins"].Properties[pluginKey]))
+	})
+})
@@ -32,6 +32,9 @@ func (c *cli) newCreateAPICmd() *cobra.Command {
 		Short:   "Scaffold a Kubernetes API",
 		Long:    ctx.Description,
 		Example: ctx.Examples,
+		Args: func(cmd *cobra.Command, args []string) error {
+			return newError(UsageError, cobra.NoArgs(cmd, args))
+		},
 		RunE: errCmdFunc(
 			fmt.Errorf("api subcommand requires an existing project"),
 		),
@@ -43,11 +46,9 @@ func (c *cli) newCreateAPICmd() *cobra.Command {
 }
 
 func (c cli) newAPIContext() plugin.Context {
-	ctx := plugin.Context{
-		CommandName: c.commandName,
-		Description: `Scaffold a Kubernetes API.
-`,
-	}
+	ctx := c.newContext()
+	ctx.Description = `Scaffold a Kubernetes API.
+`
 	if !c.configured {
 		ctx.Description = fmt.Sprintf("%s\n%s", ctx.Description, runInProjectRootMsg)
 	}
@@ -55,38 +56,42 @@ func (c cli) newAPIContext() plugin.Context {
 }
 
 func (c cli) bindCreateAPI(ctx plugin.Context, cmd *cobra.Command) {
-	var getter plugin.CreateAPIPluginGetter
+	var (
+		keys        []string
+		subcommands []plugin.GenericSubcommand
+	)
 	for _, p := range c.resolvedPlugins {
-		tmpGetter, isGetter := p.(plugin.CreateAPIPluginGetter)
-		if isGetter {
-			if getter != nil {
-				err := fmt.Errorf("duplicate API creation plugins for project version %q (%s, %s), "+
-					"use a more specific plugin key", c.projectVersion, plugin.KeyFor(getter), plugin.KeyFor(p))
-				cmdErr(cmd, err)
-				return
+		// Getters return nil if the plugin does not implement the subcommand.
+		if getter, isGetter := p.(plugin.CreateAPIPluginGetter); isGetter {
+			if sub := getter.GetCreateAPIPlugin(); sub != nil {
+				keys = append(keys, plugin.KeyFor(p))
+				subcommands = append(subcommands, sub)
 			}
-			getter = tmpGetter
 		}
 	}
 
-	cfg, err := config.LoadInitialized()
+	cfg, err := config.LoadInitializedFs(c.fs)
 	if err != nil {
 		cmdErr(cmd, err)
 		return
 	}
 
-	if getter == nil {
+	if len(subcommands) == 0 {
 		err := fmt.Errorf("layout plugin %q does not support an API creation plugin", cfg.Layout)
 		cmdErr(cmd, err)
 		return
 	}
 
-	createAPI := getter.GetCreateAPIPlugin()
+	createAPI := newSubcommandChain(keys, subcommands)
 	createAPI.InjectConfig(&cfg.Config)
 	createAPI.BindFlags(cmd.Flags())
+	if err = c.applyFlagDefaults(cmd.Flags()); err != nil {
+		cmdErr(cmd, err)
+		return
+	}
 	createAPI.UpdateContext(&ctx)
 	cmd.Long = ctx.Description
 	cmd.Example = ctx.Examples
-	cmd.RunE = runECmdFunc(cfg, createAPI,
+	cmd.RunE = c.runECmdFunc(cfg, keys, createAPI, ctx,
 		fmt.Sprintf("failed to create API with version %q", c.projectVersion))
 }
@@ -0,0 +1,116 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/pflag"
+
+	"sigs.k8s.io/kubebuilder/pkg/model/config"
+	"sigs.k8s.io/kubebuilder/pkg/plugin"
+)
+
+// subcommandChain runs the subcommands of a plugin chain in order over a shared config
+// and file system, so that each plugin builds on the files scaffolded by the previous ones.
+type subcommandChain struct {
+	// keys are the plugin keys of the subcommands, used in error messages.
+	keys        []string
+	subcommands []plugin.GenericSubcommand
+
+	// flags is the command flag set, where the first definition of each flag is registered.
+	flags *pflag.FlagSet
+	// flagSets contains the flags bound by each subcommand.
+	flagSets []*pflag.FlagSet
+}
+
+// newSubcommandChain returns a subcommand that runs subcommands in order. A single
+// subcommand is returned as is.
+func newSubcommandChain(keys []string, subcommands []plugin.GenericSubcommand) plugin.GenericSubcommand {
+	if len(subcommands) == 1 {
+		return subcommands[0]
+	}
+	return &subcommandChain{keys: keys, subcommands: subcommands}
+}
+
+// UpdateContext implements plugin.GenericSubcommand. The first subcommand provides the
+// help text, the rest of them receive a copy of the original context.
+func (c *subcommandChain) UpdateContext(ctx *plugin.Context) {
+	base := *ctx
+	c.subcommands[0].UpdateContext(ctx)
+	for _, sub := range c.subcommands[1:] {
+		subCtx := base
+		sub.UpdateContext(&subCtx)
+	}
+}
+
+// BindFlags implements plugin.GenericSubcommand. Flags declared by several subcommands
+// are only registered once and their values are shared.
+func (c *subcommandChain) BindFlags(fs *pflag.FlagSet) {
+	c.flags = fs
+	c.flagSets = make([]*pflag.FlagSet, 0, len(c.subcommands))
+	for _, sub := range c.subcommands {
+		subFs := pflag.NewFlagSet("", pflag.ContinueOnError)
+		sub.BindFlags(subFs)
+		subFs.VisitAll(func(f *pflag.Flag) {
+			if fs.Lookup(f.Name) == nil {
+				fs.AddFlag(f)
+			}
+		})
+		c.flagSets = append(c.flagSets, subFs)
+	}
+}
+
+// InjectConfig implements plugin.GenericSubcommand
+func (c *subcommandChain) InjectConfig(cfg *config.Config) {
+	for _, sub := range c.subcommands {
+		sub.InjectConfig(cfg)
+	}
+}
+
+// Run implements plugin.GenericSubcommand
+func (c *subcommandChain) Run() error {
+	for i, sub := range c.subcommands {
+		if err := c.syncFlags(c.flagSets[i]); err != nil {
+			return fmt.Errorf("plugin %q: %w", c.keys[i], err)
+		}
+		if err := sub.Run(); err != nil {
+			return fmt.Errorf("plugin %q: %w", c.keys[i], err)
+		}
+	}
+	return nil
+}
+
+// syncFlags copies the values set in the command line or by user defaults to the flags in fs
+// that were not registered in the command flag set because a previous subcommand declared them.
+func (c *subcommandChain) syncFlags(fs *pflag.FlagSet) (err error) {
+	fs.VisitAll(func(f *pflag.Flag) {
+		set := c.flags.Lookup(f.Name)
+		if err != nil || set == f || !set.Changed && !hasUserDefault(set) {
+			return
+		}
+
+		if src, isSlice := set.Value.(pflag.SliceValue); isSlice {
+			if dst, isSlice := f.Value.(pflag.SliceValue); isSlice {
+				err = dst.Replace(src.GetSlice())
+				return
+			}
+		}
+		err = f.Value.Set(set.Value.String())
+	})
+	return err
+}
@@ -0,0 +1,92 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/spf13/pflag"
+
+	"sigs.k8s.io/kubebuilder/pkg/model/config"
+	"sigs.k8s.io/kubebuilder/pkg/plugin"
+)
+
+// recordingSubcommand binds a --group flag and records the value it runs with.
+type recordingSubcommand struct {
+	name  string
+	group string
+	runs  *[]string
+}
+
+func (s *recordingSubcommand) UpdateContext(ctx *plugin.Context) { ctx.Description = s.name }
+func (s *recordingSubcommand) BindFlags(fs *pflag.FlagSet)       { fs.StringVar(&s.group, "group", "", "") }
+func (s *recordingSubcommand) InjectConfig(c *config.Config)     { c.Domain += s.name }
+func (s *recordingSubcommand) Run() error {
+	*s.runs = append(*s.runs, s.name+":"+s.group)
+	return nil
+}
+
+var _ = Describe("newSubcommandChain", func() {
+	var (
+		runs  []string
+		first plugin.GenericSubcommand
+		last  plugin.GenericSubcommand
+	)
+
+	BeforeEach(func() {
+		runs = nil
+		first = &recordingSubcommand{name: "a", runs: &runs}
+		last = &recordingSubcommand{name: "b", runs: &runs}
+	})
+
+	It("should return a single subcommand as is", func() {
+		Expect(newSubcommandChain([]string{"a"}, []plugin.GenericSubcommand{first})).To(BeIdenticalTo(first))
+	})
+
+	It("should run every subcommand in order with shared flags and config", func() {
+		chain := newSubcommandChain([]string{"a", "b"}, []plugin.GenericSubcommand{first, last})
+
+		cfg := &config.Config{}
+		chain.InjectConfig(cfg)
+		Expect(cfg.Domain).To(Equal("ab"))
+
+		fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+		chain.BindFlags(fs)
+		Expect(fs.Parse([]string{"--group", "crew"})).To(Succeed())
+
+		ctx := plugin.Context{}
+		chain.UpdateContext(&ctx)
+		Expect(ctx.Description).To(Equal("a"))
+
+		Expect(chain.Run()).To(Succeed())
+		Expect(runs).To(Equal([]string{"a:crew", "b:crew"}))
+	})
+
+	It("should share the flag defaults set by the user", func() {
+		chain := newSubcommandChain([]string{"a", "b"}, []plugin.GenericSubcommand{first, last})
+
+		fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+		chain.BindFlags(fs)
+		c := cli{flagDefaults: []flagDefault{{Name: "group", Value: "ship", Source: "KUBEBUILDER_DEFAULT_GROUP"}}}
+		Expect(c.applyFlagDefaults(fs)).To(Succeed())
+		Expect(fs.Parse(nil)).To(Succeed())
+
+		Expect(chain.Run()).To(Succeed())
+		Expect(runs).To(Equal([]string{"a:ship", "b:ship"}))
+	})
+})
@@ -18,17 +18,23 @@ package cli
 
 import (
 	"fmt"
-	"log"
+	"io"
+	"io/ioutil"
 	"os"
+	"path/filepath"
 	"strings"
+	"time"
 
+	"github.com/spf13/afero"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 
+	"sigs.k8s.io/kubebuilder/internal/cmdutil"
 	internalconfig "sigs.k8s.io/kubebuilder/internal/config"
 	"sigs.k8s.io/kubebuilder/pkg/internal/validation"
 	"sigs.k8s.io/kubebuilder/pkg/model/config"
 	"sigs.k8s.io/kubebuilder/pkg/plugin"
+	"sigs.k8s.io/kubebuilder/pkg/plugin/external"
 )
 
 const (
@@ -40,6 +46,14 @@ project.
 	projectVersionFlag = "project-version"
 	helpFlag           = "help"
 	pluginsFlag        = "plugins"
+	dryRunFlag         = "dry-run"
+	outputFlag         = "output"
+
+	outputJSON = "json"
+	outputYAML = "yaml"
+
+	// defaultLockTimeout is how long commands wait for another command holding the project lock.
+	defaultLockTimeout = time.Minute
 )
 
 // CLI interacts with a command line interface.
@@ -65,14 +79,26 @@ type cli struct {
 	configured bool
 	// Whether the command is requesting help.
 	doGenericHelp bool
+	// Whether the command should only report the changes it would make.
+	dryRun bool
+	// Format of the machine-readable report, if any.
+	output string
+	// Whether every prompt should be answered with yes.
+	assumeYes bool
+	// Whether prompting is disabled, failing if an answer is required.
+	noPrompt bool
+	// Whether the hooks declared in the project config are skipped.
+	skipHooks bool
+	// Directory the template overrides are read from, relative to the project root if not absolute.
+	templateDir string
 
 	// Plugins injected by options.
 	pluginsFromOptions map[string][]plugin.Base
 	// Default plugins injected by options. Only one plugin per project version
 	// is allowed.
 	defaultPluginsFromOptions map[string]plugin.Base
-	// A plugin key passed to --plugins on invoking 'init'.
-	cliPluginKey string
+	// The ordered plugin keys passed to --plugins on invoking 'init'.
+	cliPluginKeys []string
 	// A filtered set of plugins that should be used by command constructors.
 	resolvedPlugins []plugin.Base
 
@@ -80,15 +106,63 @@ type cli struct {
 	cmd *cobra.Command
 	// Commands injected by options.
 	extraCommands []*cobra.Command
+	// Functions completing the values of flags, see the __complete command.
+	flagCompletions map[*pflag.Flag]completionFunc
+	// Whether the command line is being completed.
+	completing bool
+	// Whether flag defaults are read from the user config file and the environment.
+	userDefaults bool
+	// Path of the user config file, if user defaults are enabled.
+	userConfigPath string
+	// Flag defaults set by the user, sorted by flag name.
+	flagDefaults []flagDefault
+	// Warnings found while applying options, printed on initialization.
+	warnings []string
+	// Whether external plugins are discovered on initialization.
+	externalPlugins bool
+
+	// Command line arguments, without the command name.
+	args []string
+	// Streams the commands read from and write to.
+	stdin  io.Reader
+	stdout io.Writer
+	stderr io.Writer
+	// Environment variables, in "key=value" form.
+	environ []string
+	// Absolute path of the project root.
+	workingDir string
+	// Whether workingDir was set by an option, rooting fs at it.
+	workingDirFromOptions bool
+	// File system the project is read from and written to, rooted at workingDir
+	// if it was set by an option.
+	fs afero.Fs
+	// File system the template overrides are read from, rooted at the template directory.
+	templates afero.Fs
+	// How long commands wait for another command holding the project lock.
+	lockTimeout time.Duration
 }
 
 // New creates a new cli instance.
 func New(opts ...Option) (CLI, error) {
+	wd, err := os.Getwd()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the working directory: %v", err)
+	}
+
 	c := &cli{
 		commandName:               "kubebuilder",
 		defaultProjectVersion:     internalconfig.DefaultVersion,
 		pluginsFromOptions:        make(map[string][]plugin.Base),
 		defaultPluginsFromOptions: make(map[string]plugin.Base),
+		flagCompletions:           make(map[*pflag.Flag]completionFunc),
+		args:                      os.Args[1:],
+		stdin:                     os.Stdin,
+		stdout:                    os.Stdout,
+		stderr:                    os.Stderr,
+		environ:                   os.Environ(),
+		workingDir:                wd,
+		fs:                        afero.NewOsFs(),
+		lockTimeout:               defaultLockTimeout,
 	}
 	for _, opt := range opts {
 		if err := opt(c); err != nil {
@@ -102,9 +176,28 @@ func New(opts ...Option) (CLI, error) {
 	return c, nil
 }
 
-// Run runs the cli.
+// Run runs the cli. The returned error, if any, has already been printed, and its
+// exit code can be obtained with ExitCode.
 func (c cli) Run() error {
-	return c.cmd.Execute()
+	c.cmd.SetArgs(c.args)
+	cmd, err := c.cmd.ExecuteC()
+	if err == nil {
+		return nil
+	}
+
+	// Errors are printed as text to stderr, or to stdout in the format requested by --output
+	// along with the report of successful runs.
+	if c.output != "" {
+		if printErr := printError(c.stdout, c.output, err); printErr != nil {
+			PrintError(c.stderr, printErr)
+		}
+		return err
+	}
+	PrintError(c.stderr, err)
+	if ErrorKindOf(err) == UsageError && !cmd.SilenceUsage {
+		fmt.Fprintln(c.stderr, cmd.UsageString())
+	}
+	return err
 }
 
 // WithCommandName is an Option that sets the cli's root command name.
@@ -144,6 +237,17 @@ func WithPlugins(plugins ...plugin.Base) Option {
 	}
 }
 
+// WithExternalPlugins is an Option that adds the external plugins found in $PATH and in
+// the user's plugins directory to the cli's plugins. Plugins that cannot be loaded, are invalid
+// or have the same key as another plugin are reported as warnings and ignored. The plugins are
+// discovered once every option is applied, and not when completing the command line.
+func WithExternalPlugins() Option {
+	return func(c *cli) error {
+		c.externalPlugins = true
+		return nil
+	}
+}
+
 // WithDefaultPlugins is an Option that sets the cli's default plugins. Only
 // one plugin per project version is allowed.
 func WithDefaultPlugins(plugins ...plugin.Base) Option {
@@ -173,6 +277,113 @@ func WithExtraCommands(cmds ...*cobra.Command) Option {
 	}
 }
 
+// WithArgs is an Option that sets the command line arguments the cli runs with,
+// without the command name. By default, the arguments of the process are used.
+func WithArgs(args ...string) Option {
+	return func(c *cli) error {
+		c.args = append([]string{}, args...)
+		return nil
+	}
+}
+
+// WithIOStreams is an Option that sets the streams the cli and its plugins read
+// from and write to. By default, the standard streams of the process are used.
+func WithIOStreams(in io.Reader, out, errOut io.Writer) Option {
+	return func(c *cli) error {
+		if in == nil || out == nil || errOut == nil {
+			return fmt.Errorf("broken pre-set IO streams: every stream must be set")
+		}
+		c.stdin, c.stdout, c.stderr = in, out, errOut
+		return nil
+	}
+}
+
+// WithWorkingDirectory is an Option that sets the project root, instead of the
+// current directory of the process. The file system is rooted at it, and the
+// commands run by plugins and hooks run in it. A relative dir is relative to the
+// current directory of the process.
+func WithWorkingDirectory(dir string) Option {
+	return func(c *cli) error {
+		if !filepath.IsAbs(dir) {
+			dir = filepath.Join(c.workingDir, dir)
+		}
+		c.workingDir = filepath.Clean(dir)
+		c.workingDirFromOptions = true
+		return nil
+	}
+}
+
+// WithEnvironment is an Option that sets the environment variables, in "key=value"
+// form, the cli reads its settings from and runs hooks with. By default, the
+// environment of the process is used.
+func WithEnvironment(environ ...string) Option {
+	return func(c *cli) error {
+		c.environ = append([]string{}, environ...)
+		return nil
+	}
+}
+
+// WithFilesystem is an Option that sets the file system the project is read
+// from and written to. By default, the file system of the OS is used.
+func WithFilesystem(fs afero.Fs) Option {
+	return func(c *cli) error {
+		if fs == nil {
+			return fmt.Errorf("broken pre-set file system: file system must be set")
+		}
+		c.fs = fs
+		return nil
+	}
+}
+
+// getenv returns the value of the environment variable key, or an empty string if it is not set.
+func (c cli) getenv(key string) string {
+	for i := len(c.environ) - 1; i >= 0; i-- {
+		if strings.HasPrefix(c.environ[i], key+"=") {
+			return strings.TrimPrefix(c.environ[i], key+"=")
+		}
+	}
+	return ""
+}
+
+// addExternalPlugins adds the discovered external plugins to the cli's plugins. Plugins that cannot be
+// loaded, are invalid or have the same key as another plugin are added to the warnings instead.
+func (c *cli) addExternalPlugins() {
+	pluginsDir, err := external.DefaultPluginsDir(c.getenv)
+	if err != nil {
+		c.warnings = append(c.warnings, err.Error())
+		return
+	}
+
+	found, err := external.Discover(c.fs, pluginsDir, c.getenv("PATH"), c.stderr)
+	if err != nil {
+		c.warnings = append(c.warnings, err.Error())
+	}
+
+	keys := make(map[string]bool)
+	for _, plugins := range c.pluginsFromOptions {
+		for _, p := range plugins {
+			keys[plugin.KeyFor(p)] = true
+		}
+	}
+	for _, p := range found {
+		key := plugin.KeyFor(p)
+		if err := validatePlugin(p); err != nil {
+			c.warnings = append(c.warnings, fmt.Sprintf("ignoring external plugin %s: %v", p.Path(), err))
+			continue
+		}
+		if keys[key] {
+			c.warnings = append(c.warnings,
+				fmt.Sprintf("ignoring external plugin %s: a plugin with key %q already exists", p.Path(), key))
+			continue
+		}
+		keys[key] = true
+
+		for _, version := range p.SupportedProjectVersions() {
+			c.pluginsFromOptions[version] = append(c.pluginsFromOptions[version], p)
+		}
+	}
+}
+
 // initialize initializes the cli.
 func (c *cli) initialize() error {
 	// Initialize cli with globally-relevant flags or flags that determine
@@ -181,9 +392,39 @@ func (c *cli) initialize() error {
 		return err
 	}
 
+	// Executing every external plugin would slow down completion, which happens on every key press.
+	if c.externalPlugins && !c.completing {
+		c.addExternalPlugins()
+	}
+
+	if !c.completing {
+		for _, warning := range c.warnings {
+			fmt.Fprintf(c.stderr, "warning: %s\n", warning)
+		}
+	}
+
+	// Root the file system at the working directory if it was set by an option.
+	root := c.fs
+	if c.workingDirFromOptions {
+		c.fs = afero.NewBasePathFs(c.fs, c.workingDir)
+	}
+	c.templates = c.newTemplatesFs(root)
+
+	// Load the flag defaults set by the user before the flags are bound.
+	if c.userDefaults {
+		configDir, err := cmdutil.UserConfigDir(c.getenv)
+		if err != nil {
+			return err
+		}
+		c.userConfigPath = filepath.Join(configDir, userConfigFile)
+		if c.flagDefaults, err = loadFlagDefaults(root, c.userConfigPath, c.workingDir, c.environ); err != nil {
+			return newError(UsageError, err)
+		}
+	}
+
 	// Configure the project version first for plugin retrieval in command
 	// constructors.
-	projectConfig, err := internalconfig.Read()
+	projectConfig, err := internalconfig.ReadFromFs(c.fs, internalconfig.DefaultPath)
 	if os.IsNotExist(err) {
 		c.configured = false
 		if c.projectVersion == "" {
@@ -194,24 +435,24 @@ func (c *cli) initialize() error {
 		c.projectVersion = projectConfig.Version
 
 		if projectConfig.IsV1() {
-			return fmt.Errorf(noticeColor, "project version 1 is no longer supported.\n"+
-				"See how to upgrade your project: https://book.kubebuilder.io/migration/guide.html\n")
+			return newError(ConflictError, fmt.Errorf(noticeColor, "project version 1 is no longer supported.\n"+
+				"See how to upgrade your project: https://book.kubebuilder.io/migration/guide.html\n"))
 		}
 	} else {
-		return fmt.Errorf("failed to read config: %v", err)
+		return newError(InternalError, fmt.Errorf("failed to read config: %v", err))
 	}
 
 	// Validate after setting projectVersion but before buildRootCmd so we error
 	// out before an error resulting from an incorrect cli is returned downstream.
 	if err = c.validate(); err != nil {
-		return err
+		return newError(UsageError, err)
 	}
 
 	// When invoking 'init', a user can:
 	// 1. Not set --plugins
-	// 2. Set --plugins to a plugin, ex. --plugins=go-x
+	// 2. Set --plugins to one or more plugins, ex. --plugins=go-x,addon-y
 	// In case 1, default plugins will be used to determine which plugin to use.
-	// In case 2, the value passed to --plugins is used.
+	// In case 2, the values passed to --plugins are used as an ordered chain.
 	// For all other commands, a config's 'layout' key is used. Since both
 	// layout and --plugins values can be short (ex. "go/v2") or unversioned
 	// (ex. "go.kubebuilder.io") keys or both, their values may need to be
@@ -221,33 +462,33 @@ func (c *cli) initialize() error {
 	// in situations like 'init --plugins "go"' when multiple go-type plugins
 	// are available but only one default is for a particular project version.
 	allPlugins := c.pluginsFromOptions[c.projectVersion]
-	defaultPlugin := []plugin.Base{c.defaultPluginsFromOptions[c.projectVersion]}
+	var defaultPlugins []plugin.Base
+	if defaultPlugin, hasDefault := c.defaultPluginsFromOptions[c.projectVersion]; hasDefault {
+		defaultPlugins = append(defaultPlugins, defaultPlugin)
+	}
 	switch {
-	case c.cliPluginKey != "":
-		// Filter plugin by keys passed in CLI.
-		if c.resolvedPlugins, err = resolvePluginsByKey(defaultPlugin, c.cliPluginKey); err != nil {
-			c.resolvedPlugins, err = resolvePluginsByKey(allPlugins, c.cliPluginKey)
-		}
+	case len(c.cliPluginKeys) != 0:
+		// Filter plugins by keys passed in CLI.
+		c.resolvedPlugins, err = resolvePluginChain(defaultPlugins, allPlugins, c.cliPluginKeys)
 	case c.configured && projectConfig.IsV3():
 		// All non-v1 configs must have a layout key. This check will help with
 		// migration.
-		layout := projectConfig.Layout
-		if layout == "" {
+		if len(projectConfig.Layout) == 0 {
 			return fmt.Errorf("config must have a layout value")
 		}
-		// Filter plugin by config's layout value.
-		if c.resolvedPlugins, err = resolvePluginsByKey(defaultPlugin, layout); err != nil {
-			c.resolvedPlugins, err = resolvePluginsByKey(allPlugins, layout)
-		}
+		// Filter plugins by config's layout value.
+		c.resolvedPlugins, err = resolvePluginChain(defaultPlugins, allPlugins, projectConfig.Layout)
 	default:
 		// Use the default plugins for this project version.
-		c.resolvedPlugins = defaultPlugin
+		c.resolvedPlugins = defaultPlugins
 	}
 	if err != nil {
-		return err
+		return newError(UsageError, err)
 	}
 
-	c.cmd = c.buildRootCmd()
+	if c.cmd, err = c.buildRootCmd(); err != nil {
+		return err
+	}
 
 	// Add extra commands injected by options.
 	for _, cmd := range c.extraCommands {
@@ -258,11 +499,15 @@ func (c *cli) initialize() error {
 		}
 		c.cmd.AddCommand(cmd)
 	}
+	c.cmd.SetIn(c.stdin)
+	c.cmd.SetOut(c.stdout)
+	c.cmd.SetErr(c.stderr)
 
-	// Write deprecation notices after all commands have been constructed.
+	// Write deprecation notices after all commands have been constructed,
+	// unless they would be taken as completion candidates.
 	for _, p := range c.resolvedPlugins {
-		if d, isDeprecated := p.(plugin.Deprecated); isDeprecated {
-			fmt.Printf(noticeColor, fmt.Sprintf("[Deprecation Notice] %s\n\n",
+		if d, isDeprecated := p.(plugin.Deprecated); isDeprecated && !c.completing {
+			fmt.Fprintf(c.stdout, noticeColor, fmt.Sprintf("[Deprecation Notice] %s\n\n",
 				d.DeprecationWarning()))
 		}
 	}
@@ -274,23 +519,47 @@ func (c *cli) initialize() error {
 // affect initialization of a cli. An error is returned only if an error
 // unrelated to flag parsing occurs.
 func (c *cli) parseBaseFlags() error {
+	args := c.args
+	errorHandling := pflag.ExitOnError
+	// When completing, the last word may be incomplete and the previous ones
+	// follow the hidden command. Parse errors must not print anything.
+	if len(args) != 0 && args[0] == completeCmdName {
+		c.completing = true
+		errorHandling = pflag.ContinueOnError
+		args = args[1:]
+		if len(args) != 0 {
+			args = args[:len(args)-1]
+		}
+	}
+
 	// Create a dummy "base" flagset to populate from CLI args.
-	fs := pflag.NewFlagSet("base", pflag.ExitOnError)
+	fs := pflag.NewFlagSet("base", errorHandling)
 	fs.ParseErrorsWhitelist = pflag.ParseErrorsWhitelist{UnknownFlags: true}
+	if c.completing {
+		fs.SetOutput(ioutil.Discard)
+	}
 
 	var help bool
 	// Set base flags that require pre-parsing to initialize c.
 	fs.BoolVarP(&help, helpFlag, "h", false, "print help")
 	fs.StringVar(&c.projectVersion, projectVersionFlag, c.defaultProjectVersion, "project version")
-	fs.StringVar(&c.cliPluginKey, pluginsFlag, "", "plugins to run")
+	fs.StringSliceVar(&c.cliPluginKeys, pluginsFlag, nil, "plugins to run")
+	fs.BoolVar(&c.dryRun, dryRunFlag, false, "dry run")
+	fs.BoolVar(&c.assumeYes, yesFlag, false, "assume yes")
+	fs.BoolVar(&c.noPrompt, noPromptFlag, false, "disable prompts")
+	fs.BoolVar(&c.skipHooks, skipHooksFlag, false, "skip hooks")
+	fs.StringVar(&c.templateDir, templateDirFlag, "", "template directory")
+	fs.StringVar(&c.output, outputFlag, "", "output format")
 
 	// Parse current CLI args outside of cobra.
-	err := fs.Parse(os.Args[1:])
+	err := fs.Parse(args)
 	// User needs *generic* help if args are incorrect or --help is set and
 	// --project-version is not set. Plugin-specific help is given if a
 	// plugin.Context is updated, which does not require this field.
 	c.doGenericHelp = err != nil || help && !fs.Lookup(projectVersionFlag).Changed
-	c.cliPluginKey = strings.TrimSpace(c.cliPluginKey)
+	for i, key := range c.cliPluginKeys {
+		c.cliPluginKeys[i] = strings.TrimSpace(key)
+	}
 
 	return nil
 }
@@ -308,16 +577,21 @@ func (c cli) validate() error {
 	// If --plugins is not set, no layout exists (no config or project is v1 or v2),
 	// and no defaults exist, we cannot know which plugins to use.
 	isLayoutSupported := c.projectVersion == config.Version3Alpha
-	if (!c.configured || !isLayoutSupported) && c.cliPluginKey == "" {
+	if (!c.configured || !isLayoutSupported) && len(c.cliPluginKeys) == 0 {
 		_, versionExists := c.defaultPluginsFromOptions[c.projectVersion]
 		if !versionExists {
 			return fmt.Errorf("no default plugins for project version %q", c.projectVersion)
 		}
 	}
 
+	// Validate output format.
+	if c.output != "" && c.output != outputJSON && c.output != outputYAML {
+		return fmt.Errorf("invalid output format %q, possible values: (%q, %q)", c.output, outputJSON, outputYAML)
+	}
+
 	// Validate plugin keys set in CLI.
-	if c.cliPluginKey != "" {
-		pluginName, pluginVersion := plugin.SplitKey(c.cliPluginKey)
+	for _, pluginKey := range c.cliPluginKeys {
+		pluginName, pluginVersion := plugin.SplitKey(pluginKey)
 		if err := plugin.ValidateName(pluginName); err != nil {
 			return fmt.Errorf("invalid plugin name %q: %v", pluginName, err)
 		}
@@ -334,16 +608,39 @@ func (c cli) validate() error {
 
 // buildRootCmd returns a root command with a subcommand tree reflecting the
 // current project's state.
-func (c cli) buildRootCmd() *cobra.Command {
+func (c cli) buildRootCmd() (*cobra.Command, error) {
 	rootCmd := c.defaultCommand()
 
+	// Errors and usage are printed by Run, flag errors are caused by the user input.
+	rootCmd.SilenceErrors = true
+	rootCmd.SilenceUsage = true
+	rootCmd.SetFlagErrorFunc(func(_ *cobra.Command, err error) error {
+		return newError(UsageError, err)
+	})
+
+	// Register --dry-run for every subcommand, it was already parsed by parseBaseFlags.
+	rootCmd.PersistentFlags().Bool(dryRunFlag, false,
+		"if set, print the changes that would be made to the project instead of writing them to disk")
+	// Register the prompt flags for every subcommand, they were already parsed by parseBaseFlags.
+	rootCmd.PersistentFlags().Bool(yesFlag, false,
+		"if set, answer yes to every prompt")
+	rootCmd.PersistentFlags().Bool(noPromptFlag, false,
+		"if set, fail instead of prompting when a required flag is missing, implied if stdin is not a terminal")
+	// Register --skip-hooks for every subcommand, it was already parsed by parseBaseFlags.
+	rootCmd.PersistentFlags().Bool(skipHooksFlag, false,
+		"if set, do not run the hooks declared in the project config")
+	// Register --template-dir for every subcommand, it was already parsed by parseBaseFlags.
+	rootCmd.PersistentFlags().String(templateDirFlag, "",
+		fmt.Sprintf("directory the template overrides are read from, relative to the project root "+
+			"(default %q)", defaultTemplateDir))
+	rootCmd.PersistentFlags().String(outputFlag, "",
+		fmt.Sprintf("if set, print a report of the changes in the given format (%s, %s)", outputJSON, outputYAML))
+
 	// kubebuilder alpha
 	alphaCmd := c.newAlphaCmd()
-
-	// Only add alpha group if it has subcommands
-	if alphaCmd.HasSubCommands() {
-		rootCmd.AddCommand(alphaCmd)
-	}
+	rootCmd.AddCommand(alphaCmd)
+	// kubebuilder alpha config
+	alphaCmd.AddCommand(c.newAlphaConfigCmd())
 
 	// kubebuilder create
 	createCmd := c.newCreateCmd()
@@ -354,10 +651,43 @@ func (c cli) buildRootCmd() *cobra.Command {
 		rootCmd.AddCommand(createCmd)
 	}
 
+	// kubebuilder delete
+	deleteCmd := c.newDeleteCmd()
+	// kubebuilder delete api
+	deleteCmd.AddCommand(c.newDeleteAPICmd())
+	deleteCmd.AddCommand(c.newDeleteWebhookCmd())
+	if deleteCmd.HasSubCommands() {
+		rootCmd.AddCommand(deleteCmd)
+	}
+
+	// kubebuilder edit
+	rootCmd.AddCommand(c.newEditCmd())
+
 	// kubebuilder init
 	rootCmd.AddCommand(c.newInitCmd())
 
-	return rootCmd
+	// kubebuilder plugins
+	rootCmd.AddCommand(c.newPluginsCmd())
+
+	// kubebuilder config
+	if c.userConfigPath != "" {
+		rootCmd.AddCommand(c.newConfigCmd())
+	}
+
+	// kubebuilder completion
+	rootCmd.AddCommand(c.newCompletionCmd(), c.newCompleteCmd())
+
+	// Commands contributed by the layout plugins and by the plugins for the project version
+	if err := c.addPluginCommands(rootCmd, alphaCmd); err != nil {
+		return nil, err
+	}
+
+	// Only keep the alpha group if it has subcommands
+	if !alphaCmd.HasSubCommands() {
+		rootCmd.RemoveCommand(alphaCmd)
+	}
+
+	return rootCmd, nil
 }
 
 // defaultCommand returns the root command without its subcommands.
@@ -408,10 +738,14 @@ After the scaffold is written, api will run make on the project.
 `,
 			c.commandName, c.commandName),
 
-		Run: func(cmd *cobra.Command, args []string) {
-			if err := cmd.Help(); err != nil {
-				log.Fatal(err)
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 0 {
+				return newError(UsageError, fmt.Errorf("unknown command %q for %q", args[0], cmd.CommandPath()))
 			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
 		},
 	}
 }
@@ -17,23 +17,70 @@ limitations under the License.
 package cli
 
 import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
 	"testing"
+	"time"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 
+	"github.com/spf13/afero"
 	"github.com/spf13/pflag"
 
 	internalconfig "sigs.k8s.io/kubebuilder/internal/config"
 	"sigs.k8s.io/kubebuilder/pkg/model/config"
 	"sigs.k8s.io/kubebuilder/pkg/plugin"
+	pluginv2 "sigs.k8s.io/kubebuilder/pkg/plugin/v2"
+	pluginv3 "sigs.k8s.io/kubebuilder/pkg/plugin/v3"
 )
 
+// testCLIEnv is set to the path of the test binary to run it as the cli instead of running the
+// tests, so that the tests can run hooks calling the cli.
+const testCLIEnv = "KUBEBUILDER_TEST_CLI"
+
+func TestMain(m *testing.M) {
+	if os.Getenv(testCLIEnv) != "" {
+		c, err := New(
+			WithPlugins(&pluginv2.Plugin{}, &pluginv3.Plugin{}),
+			WithDefaultPlugins(&pluginv2.Plugin{}),
+			// Fail fast instead of waiting for a lock held by the test
+			func(c *cli) error {
+				c.lockTimeout = time.Second
+				return nil
+			},
+		)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		os.Exit(ExitCode(c.Run()))
+	}
+	os.Exit(m.Run())
+}
+
 func TestCLI(t *testing.T) {
 	RegisterFailHandler(Fail)
 	RunSpecs(t, "CLI Suite")
 }
 
+// runProject runs a cli with the built-in plugins and args over the project at dir of fs,
+// writing to stdout and stderr.
+func runProject(fs afero.Fs, dir string, stdout, stderr io.Writer, args ...string) error {
+	c, err := New(
+		WithPlugins(&pluginv2.Plugin{}, &pluginv3.Plugin{}),
+		WithDefaultPlugins(&pluginv2.Plugin{}),
+		WithArgs(args...),
+		WithIOStreams(&bytes.Buffer{}, stdout, stderr),
+		WithWorkingDirectory(dir),
+		WithFilesystem(fs),
+	)
+	Expect(err).NotTo(HaveOccurred())
+	return c.Run()
+}
+
 // Test plugin types and constructors.
 type mockPlugin struct {
 	name            string
@@ -71,11 +118,13 @@ type mockAllPlugin struct {
 	mockInitPlugin
 	mockCreateAPIPlugin
 	mockCreateWebhookPlugin
+	mockEditPlugin
 }
 
 type mockInitPlugin struct{ mockPlugin }
 type mockCreateAPIPlugin struct{ mockPlugin }
 type mockCreateWebhookPlugin struct{ mockPlugin }
+type mockEditPlugin struct{ mockPlugin }
 
 // GetInitPlugin will return the plugin which is responsible for initialized the project
 func (p mockInitPlugin) GetInitPlugin() plugin.Init { return p }
@@ -86,6 +135,9 @@ func (p mockCreateAPIPlugin) GetCreateAPIPlugin() plugin.CreateAPI { return p }
 // GetCreateWebhookPlugin will return the plugin which is responsible for scaffolding webhooks for the project
 func (p mockCreateWebhookPlugin) GetCreateWebhookPlugin() plugin.CreateWebhook { return p }
 
+// GetEditPlugin will return the plugin which is responsible for editing the scaffold of the project
+func (p mockEditPlugin) GetEditPlugin() plugin.Edit { return p }
+
 func makeAllPlugin(name, version string, projectVersions ...string) plugin.Base {
 	p := makeBasePlugin(name, version, projectVersions...).(mockPlugin)
 	return mockAllPlugin{
@@ -93,6 +145,7 @@ func makeAllPlugin(name, version string, projectVersions ...string) plugin.Base
 		mockInitPlugin{p},
 		mockCreateAPIPlugin{p},
 		mockCreateWebhookPlugin{p},
+		mockEditPlugin{p},
 	}
 }
 
@@ -105,3 +158,16 @@ func makeSetByProjVer(ps ...plugin.Base) map[string][]plugin.Base {
 	}
 	return set
 }
+
+type mockExtraCommandsPlugin struct {
+	mockPlugin
+	extraCommands []plugin.ExtraCommand
+}
+
+// GetExtraCommands will return the commands contributed by the plugin
+func (p mockExtraCommandsPlugin) GetExtraCommands() []plugin.ExtraCommand { return p.extraCommands }
+
+func makeExtraCommandsPlugin(name, version string, extraCommands ...plugin.ExtraCommand) plugin.Base {
+	p := makeBasePlugin(name, version, internalconfig.DefaultVersion).(mockPlugin)
+	return mockExtraCommandsPlugin{p, extraCommands}
+}
@@ -17,13 +17,21 @@ limitations under the License.
 package cli
 
 import (
+	"bytes"
+	"io/ioutil"
 	"os"
+	"path/filepath"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 
+	"github.com/spf13/afero"
+
+	"sigs.k8s.io/kubebuilder/internal/cmdutil"
+	internalconfig "sigs.k8s.io/kubebuilder/internal/config"
 	"sigs.k8s.io/kubebuilder/pkg/model/config"
 	"sigs.k8s.io/kubebuilder/pkg/plugin"
+	"sigs.k8s.io/kubebuilder/pkg/plugin/external"
 )
 
 var _ = Describe("CLI", func() {
@@ -141,6 +149,19 @@ var _ = Describe("CLI", func() {
 				Expect(c.(*cli).resolvedPlugins).To(Equal([]plugin.Base{pluginBV2}))
 			})
 
+			It("should resolve a chain of plugins in order", func() {
+				By(`setting cliPluginKeys to "go.test.com/v2,go/v1"`)
+				setPluginsFlag("go.test.com/v2,go/v1")
+				c, err = New(WithDefaultPlugins(pluginAV1), WithPlugins(allPlugins...))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(c.(*cli).resolvedPlugins).To(Equal([]plugin.Base{pluginBV2, pluginAV1}))
+
+				By(`setting cliPluginKeys to the same plugin twice`)
+				setPluginsFlag("go/v1,go.example.com/v1")
+				_, err = New(WithDefaultPlugins(pluginAV1), WithPlugins(allPlugins...))
+				Expect(err).To(MatchError(`plugin "go.example.com/v1" appears more than once in the plugin chain`))
+			})
+
 			It("should return an error", func() {
 				By(`setting cliPluginKey to an non-existent key "foo"`)
 				setPluginsFlag("foo")
@@ -152,10 +173,229 @@ var _ = Describe("CLI", func() {
 			})
 		})
 
+		Context("with plugins contributing extra commands", func() {
+			It("should add the commands to the alpha group or the root command", func() {
+				p := makeExtraCommandsPlugin(pluginNameA, "v1",
+					plugin.ExtraCommand{Name: "generate-bundle", Alpha: true, Subcommand: mockPlugin{}},
+					plugin.ExtraCommand{Name: "bundle", Subcommand: mockPlugin{}},
+				)
+				c, err = New(WithDefaultPlugins(p), WithPlugins(p))
+				Expect(err).NotTo(HaveOccurred())
+
+				cmd, _, err := c.(*cli).cmd.Find([]string{"alpha", "generate-bundle"})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cmd.Name()).To(Equal("generate-bundle"))
+				cmd, _, err = c.(*cli).cmd.Find([]string{"bundle"})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cmd.Name()).To(Equal("bundle"))
+			})
+
+			It("should add the commands of other plugins to projects of the versions they declare", func() {
+				p := makeExtraCommandsPlugin(pluginNameB, "v1",
+					plugin.ExtraCommand{Name: "migrate", Alpha: true, Subcommand: mockPlugin{},
+						ProjectVersions: []string{internalconfig.DefaultVersion}},
+					plugin.ExtraCommand{Name: "upgrade", Alpha: true, Subcommand: mockPlugin{},
+						ProjectVersions: []string{config.Version2}},
+				)
+				c, err = New(WithDefaultPlugins(pluginAV1), WithPlugins(pluginAV1, p))
+				Expect(err).NotTo(HaveOccurred())
+
+				cmd, _, err := c.(*cli).cmd.Find([]string{"alpha", "migrate"})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(cmd.Name()).To(Equal("migrate"))
+				cmd, _, _ = c.(*cli).cmd.Find([]string{"alpha", "upgrade"})
+				Expect(cmd.Name()).NotTo(Equal("upgrade"))
+			})
+
+			It("should return an error if a command already exists", func() {
+				p := makeExtraCommandsPlugin(pluginNameA, "v1",
+					plugin.ExtraCommand{Name: "init", Subcommand: mockPlugin{}},
+				)
+				_, err = New(WithDefaultPlugins(p), WithPlugins(p))
+				Expect(err).To(MatchError(`plugin "go.example.com/v1" command "kubebuilder init" already exists`))
+			})
+		})
+
+		Context("with --dry-run set", func() {
+
+			var (
+				args []string
+			)
+
+			BeforeEach(func() {
+				args = os.Args
+			})
+
+			AfterEach(func() {
+				os.Args = args
+			})
+
+			It("should keep writes in memory", func() {
+				os.Args = append(os.Args, "init", "--"+dryRunFlag)
+				c, err = New(WithDefaultPlugins(pluginAV1), WithPlugins(pluginAV1))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(c.(*cli).dryRun).To(BeTrue())
+
+				ctx := c.(*cli).newContext()
+				Expect(ctx.DryRun).To(BeTrue())
+				Expect(ctx.Report).NotTo(BeNil())
+				Expect(ctx.Filesystem).To(BeAssignableToTypeOf(&cmdutil.StagingFs{}))
+			})
+		})
+
+		Context("embedded in another program", func() {
+			It("should run with the injected args and streams", func() {
+				var stdout, stderr bytes.Buffer
+				c, err = New(WithDefaultPlugins(pluginAV1), WithPlugins(pluginAV1, pluginBV2),
+					WithArgs("plugins", "list"),
+					WithIOStreams(&bytes.Buffer{}, &stdout, &stderr),
+				)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(c.Run()).To(Succeed())
+				Expect(stdout.String()).To(ContainSubstring("go.example.com/v1"))
+				Expect(stdout.String()).To(ContainSubstring("go.test.com/v2"))
+				Expect(stderr.String()).To(BeEmpty())
+			})
+
+			It("should read the project from the injected file system and working directory", func() {
+				dir := filepath.Join(string(filepath.Separator), "projects", "crew")
+				fs := afero.NewMemMapFs()
+				Expect(afero.WriteFile(fs, filepath.Join(dir, "PROJECT"), []byte("version: \"2\"\n"), 0644)).To(Succeed())
+
+				c, err = New(WithDefaultPlugins(pluginAV1), WithPlugins(pluginAV1),
+					WithArgs("create", "api"),
+					WithWorkingDirectory(dir),
+					WithFilesystem(fs),
+				)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(c.(*cli).configured).To(BeTrue())
+				Expect(c.(*cli).projectVersion).To(Equal(config.Version2))
+
+				ctx := c.(*cli).newContext()
+				Expect(ctx.WorkingDirectory).To(Equal(dir))
+				Expect(afero.Exists(ctx.Filesystem, "PROJECT")).To(BeTrue())
+			})
+
+			It("should read the user defaults from the injected file system and environment", func() {
+				configHome := filepath.Join(string(filepath.Separator), "config")
+				configPath := filepath.Join(configHome, "kubebuilder", userConfigFile)
+				fs := afero.NewMemMapFs()
+				Expect(afero.WriteFile(fs, configPath, []byte("defaults:\n  domain: example.org\n"), 0644)).To(Succeed())
+
+				c, err = New(WithDefaultPlugins(pluginAV1), WithPlugins(pluginAV1),
+					WithArgs("init"),
+					WithFilesystem(fs),
+					WithEnvironment("XDG_CONFIG_HOME="+configHome, "KUBEBUILDER_DEFAULT_OWNER=The Crew"),
+					WithUserDefaults(),
+				)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(c.(*cli).flagDefaults).To(Equal([]flagDefault{
+					{Name: "domain", Value: "example.org", Source: configPath},
+					{Name: "owner", Value: "The Crew", Source: "KUBEBUILDER_DEFAULT_OWNER"},
+				}))
+			})
+
+			It("should print the errors and return their exit code", func() {
+				var stdout, stderr bytes.Buffer
+				c, err = New(WithDefaultPlugins(pluginAV1), WithPlugins(pluginAV1),
+					WithArgs("unknown"),
+					WithIOStreams(&bytes.Buffer{}, &stdout, &stderr),
+				)
+				Expect(err).NotTo(HaveOccurred())
+				err = c.Run()
+				Expect(ExitCode(err)).To(Equal(2))
+				Expect(stdout.String()).To(BeEmpty())
+				Expect(stderr.String()).To(HavePrefix("Error: unknown command \"unknown\" for \"kubebuilder\"\n"))
+			})
+
+			It("should reject the positional args of the scaffolding commands", func() {
+				dir := filepath.Join(string(filepath.Separator), "projects", "crew")
+				fs := afero.NewMemMapFs()
+				Expect(afero.WriteFile(fs, filepath.Join(dir, "PROJECT"),
+					[]byte("domain: example.com\nlayout: go.kubebuilder.io/v3-alpha\nrepo: example.com/crew\n"+
+						"version: 3-alpha\n"), 0644)).To(Succeed())
+
+				for _, args := range [][]string{
+					{"create", "api", "--group", "crew", "--version", "v1", "--kind", "Captain", "--force", "samples"},
+					{"create", "webhook", "--group", "crew", "--version", "v1", "--kind", "Captain", "--force", "samples"},
+				} {
+					var stdout, stderr bytes.Buffer
+					err = runProject(fs, dir, &stdout, &stderr, args...)
+					Expect(ExitCode(err)).To(Equal(2))
+					Expect(stderr.String()).To(ContainSubstring("unknown command \"samples\""))
+				}
+			})
+
+			It("should return an error if a stream is missing", func() {
+				_, err = New(WithDefaultPlugins(pluginAV1), WithPlugins(pluginAV1),
+					WithIOStreams(nil, &bytes.Buffer{}, &bytes.Buffer{}))
+				Expect(err).To(MatchError("broken pre-set IO streams: every stream must be set"))
+			})
+		})
+
+		Context("with external plugins", func() {
+			var (
+				dir            string
+				environ        []string
+				stdout, stderr bytes.Buffer
+			)
+
+			BeforeEach(func() {
+				dir, err = ioutil.TempDir("", "kubebuilder-cli-")
+				Expect(err).NotTo(HaveOccurred())
+				environ = []string{"PATH=" + dir + string(os.PathListSeparator) + os.Getenv("PATH"), "XDG_CONFIG_HOME=" + dir}
+				stdout.Reset()
+				stderr.Reset()
+
+				// The plugin records that it ran and has the same key as pluginAV1
+				Expect(ioutil.WriteFile(filepath.Join(dir, external.ExecutablePrefix+"dup"), []byte(`#!/bin/sh
+touch "$(dirname "$0")/ran"
+echo '{"apiVersion":"v1alpha1","metadata":{"name":"go.example.com","version":"v1",'\
+'"supportedProjectVersions":["3-alpha"]}}'
+`), 0700)).To(Succeed())
+			})
+
+			AfterEach(func() {
+				Expect(os.RemoveAll(dir)).To(Succeed())
+			})
+
+			ran := func() bool {
+				_, err := os.Stat(filepath.Join(dir, "ran"))
+				return err == nil
+			}
+
+			It("should warn about and ignore the plugins with the key of another plugin", func() {
+				c, err = New(WithDefaultPlugins(pluginAV1), WithPlugins(pluginAV1), WithExternalPlugins(),
+					WithArgs("plugins", "list"),
+					WithIOStreams(&bytes.Buffer{}, &stdout, &stderr),
+					WithEnvironment(environ...),
+				)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(c.Run()).To(Succeed())
+				Expect(ran()).To(BeTrue())
+				Expect(stderr.String()).To(ContainSubstring(
+					`warning: ignoring external plugin ` + filepath.Join(dir, external.ExecutablePrefix+"dup") +
+						`: a plugin with key "go.example.com/v1" already exists`))
+				Expect(stdout.String()).NotTo(ContainSubstring(dir))
+			})
+
+			It("should not discover the plugins when completing the command line", func() {
+				c, err = New(WithDefaultPlugins(pluginAV1), WithPlugins(pluginAV1), WithExternalPlugins(),
+					WithArgs(completeCmdName, ""),
+					WithIOStreams(&bytes.Buffer{}, &stdout, &stderr),
+					WithEnvironment(environ...),
+				)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(c.Run()).To(Succeed())
+				Expect(ran()).To(BeFalse())
+				Expect(stderr.String()).To(BeEmpty())
+			})
+		})
 	})
 
 })
 
+// setPluginsFlag replaces the command line arguments, as repeated --plugins flags would add to the chain.
 func setPluginsFlag(key string) {
-	os.Args = append(os.Args, "init", "--"+pluginsFlag, key)
+	os.Args = []string{os.Args[0], "init", "--" + pluginsFlag, key}
 }
@@ -17,19 +17,25 @@ limitations under the License.
 package cli
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 
 	"github.com/spf13/cobra"
 
+	"sigs.k8s.io/kubebuilder/internal/cmdutil"
 	"sigs.k8s.io/kubebuilder/internal/config"
+	"sigs.k8s.io/kubebuilder/pkg/model/file"
 	"sigs.k8s.io/kubebuilder/pkg/plugin"
+	"sigs.k8s.io/yaml"
 )
 
 // cmdErr updates a cobra command to output error information when executed
 // or used with the help flag.
 func cmdErr(cmd *cobra.Command, err error) {
 	cmd.Long = fmt.Sprintf("%s\nNote: %v", cmd.Long, err)
-	cmd.RunE = errCmdFunc(err)
+	cmd.RunE = errCmdFunc(newError(UsageError, err))
 }
 
 // cmdErrNoHelp calls cmdErr(cmd, err) then turns cmd's usage off.
@@ -46,15 +52,187 @@ func errCmdFunc(err error) func(*cobra.Command, []string) error {
 }
 
 // runECmdFunc returns a cobra RunE function that runs gsub and saves the
-// config, which may have been modified by gsub.
-func runECmdFunc(
-	c *config.Config,
+// config, which may have been modified by gsub, between the command hooks.
+// In dry-run mode the config is not saved. The changes are reported as requested by --dry-run and --output.
+func (c cli) runECmdFunc(
+	cfg *config.Config,
+	keys []string,
 	gsub plugin.GenericSubcommand, // nolint:interfacer
+	ctx plugin.Context,
 	msg string) func(*cobra.Command, []string) error {
-	return func(*cobra.Command, []string) error {
-		if err := gsub.Run(); err != nil {
-			return fmt.Errorf("%s: %v", msg, err)
+	return func(cmd *cobra.Command, _ []string) error {
+		configChange := file.Change{Path: cfg.Path(), Operation: file.Overwritten}
+		return c.runSubcommand(cmd, cfg, keys, ctx, configChange, func() error {
+			return runAndSaveConfig(cfg, ctx, gsub.Run, msg)
+		})
+	}
+}
+
+// runSubcommand calls run, which must run a subcommand and save its config cfg, between
+// the hooks of cmd, and reports the changes recorded in ctx plus configChange afterwards.
+// The messages printed by plugins go to ctx.Stdout, which is stderr if the report is printed to stdout.
+func (c cli) runSubcommand(
+	cmd *cobra.Command,
+	cfg *config.Config,
+	keys []string,
+	ctx plugin.Context,
+	configChange file.Change,
+	run func() error) error {
+	if err := c.runWithHooks(cmd, &cfg.Config, ctx, configChange, func() error {
+		// Every plugin of the chain adds to the report, which is sorted once they all ran so that
+		// the report and the post hooks see the changes in the same order on every run
+		defer ctx.Report.Sort()
+		return c.runLocked(cfg, ctx, run)
+	}); err != nil {
+		return err
+	}
+
+	switch {
+	case c.output != "":
+		return printOutput(c.stdout, c.output, operationReport{
+			Plugins:     keys,
+			DryRun:      ctx.DryRun,
+			Changes:     append(ctx.Report.Changes(), configChange),
+			Commands:    ctx.Report.Commands(),
+			ManualSteps: ctx.Report.ManualSteps(),
+		})
+	case ctx.DryRun:
+		printDryRunReport(c.stdout, ctx.Report, configChange)
+	}
+	printManualSteps(c.stdout, ctx.Report.ManualSteps())
+	return nil
+}
+
+// runLocked calls run holding the project lock of cfg, unless in dry-run mode. The lock is
+// not held while the hooks run, so that they can run other commands in the project.
+func (c cli) runLocked(cfg *config.Config, ctx plugin.Context, run func() error) (err error) {
+	if !ctx.DryRun {
+		lock, err := config.LockFs(c.fs, cfg.Path(), c.lockTimeout)
+		if err != nil {
+			return newError(ConflictError, err)
+		}
+		defer func() {
+			if unlockErr := lock.Unlock(); unlockErr != nil && err == nil {
+				err = newError(InternalError, unlockErr)
+			}
+		}()
+
+		// Another command may have modified the project while waiting for the lock
+		if err := cfg.CheckUnmodified(); err != nil {
+			return newError(ConflictError, fmt.Errorf("%w, run the command again", err))
 		}
-		return c.Save()
 	}
+
+	return run()
+}
+
+// runAndSaveConfig calls run and saves cfg unless in dry-run mode.
+// The scaffold is written before the post-scaffold step, so cfg is also saved if only that step failed.
+func runAndSaveConfig(cfg *config.Config, ctx plugin.Context, run func() error, msg string) error {
+	runErr := run()
+	if runErr != nil {
+		runErr = newRunError(fmt.Errorf("%s: %w", msg, runErr))
+	}
+	if ctx.DryRun || runErr != nil && !cmdutil.IsPostScaffoldError(runErr) {
+		return runErr
+	}
+
+	if err := saveConfig(cfg); err != nil {
+		return err
+	}
+	return runErr
+}
+
+// saveConfig saves cfg, failing with a conflict if the file was modified since it was loaded.
+func saveConfig(cfg *config.Config) error {
+	err := cfg.Save()
+	if errors.Is(err, config.ErrModified) {
+		return newError(ConflictError, err)
+	}
+	return newError(InternalError, err)
+}
+
+// newContext returns a plugin context with the runtime fields shared by every subcommand.
+func (c cli) newContext() plugin.Context {
+	fs, templates := c.fs, c.templates
+	if c.dryRun {
+		// Reads fall through to the cli file systems while writes and removals are staged and never committed.
+		fs, templates = cmdutil.NewStagingFs(fs), cmdutil.NewStagingFs(templates)
+	}
+
+	stdout := c.stdout
+	if c.output != "" {
+		// Keep stdout for the report, the messages printed by plugins and the
+		// commands they run go to stderr instead.
+		stdout = c.stderr
+	}
+
+	return plugin.Context{
+		CommandName:      c.commandName,
+		Filesystem:       fs,
+		Report:           &file.Report{},
+		DryRun:           c.dryRun,
+		Prompter:         c.newPrompter(stdout),
+		Stdout:           stdout,
+		Stderr:           c.stderr,
+		WorkingDirectory: c.workingDir,
+		Templates:        templates,
+	}
+}
+
+// printDryRunReport prints to w the changes a subcommand would have made to the
+// project, including the config file which would have been saved.
+func printDryRunReport(w io.Writer, report *file.Report, configChange file.Change) {
+	fmt.Fprintln(w, "Dry run: no changes were written to disk. The following changes would be made:")
+	for _, change := range append(report.Changes(), configChange) {
+		fmt.Fprintf(w, "  %-14s %s\n", change.Operation, change.Path)
+	}
+}
+
+// printManualSteps prints to w the steps left to the user by a subcommand, if any.
+func printManualSteps(w io.Writer, steps []string) {
+	if len(steps) == 0 {
+		return
+	}
+	fmt.Fprintln(w, "The following steps must be performed manually:")
+	for _, step := range steps {
+		fmt.Fprintf(w, "  - %s\n", step)
+	}
+}
+
+// operationReport is the machine-readable report printed when --output is set.
+type operationReport struct {
+	// Plugins are the keys of the plugins that ran, in order.
+	Plugins []string `json:"plugins"`
+	// DryRun is true if the changes were not written to disk.
+	DryRun bool `json:"dryRun,omitempty"`
+	// Changes are the operations performed on each file.
+	Changes []file.Change `json:"changes"`
+	// Commands are the post-scaffolding commands that were run.
+	Commands []string `json:"commands,omitempty"`
+	// ManualSteps are the steps that could not be performed automatically.
+	ManualSteps []string `json:"manualSteps,omitempty"`
+}
+
+// printOutput writes v, a report or any other machine-readable value, to w in the given output format.
+func printOutput(w io.Writer, output string, v interface{}) error {
+	var (
+		b   []byte
+		err error
+	)
+	switch output {
+	case outputJSON:
+		b, err = json.MarshalIndent(v, "", "  ")
+		b = append(b, '\n')
+	case outputYAML:
+		b, err = yaml.Marshal(v)
+	default:
+		err = fmt.Errorf("unknown output format %q", output)
+	}
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(b)
+	return err
 }
@@ -0,0 +1,236 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+
+	"sigs.k8s.io/kubebuilder/internal/cmdutil"
+	internalconfig "sigs.k8s.io/kubebuilder/internal/config"
+	"sigs.k8s.io/kubebuilder/pkg/model/config"
+	"sigs.k8s.io/kubebuilder/pkg/model/file"
+	"sigs.k8s.io/kubebuilder/pkg/plugin"
+	"sigs.k8s.io/kubebuilder/pkg/plugin/scaffold"
+)
+
+var _ = Describe("printOutput", func() {
+	var (
+		out    bytes.Buffer
+		report = operationReport{
+			Plugins:  []string{"go.kubebuilder.io/v3-alpha"},
+			Changes:  []file.Change{{Path: "PROJECT", Operation: file.Created}},
+			Commands: []string{"make"},
+		}
+	)
+
+	BeforeEach(func() {
+		out.Reset()
+	})
+
+	It("should print the report as JSON", func() {
+		Expect(printOutput(&out, outputJSON, report)).To(Succeed())
+		Expect(out.String()).To(MatchJSON(`{
+			"plugins": ["go.kubebuilder.io/v3-alpha"],
+			"changes": [{"path": "PROJECT", "operation": "created"}],
+			"commands": ["make"]
+		}`))
+	})
+
+	It("should print the report as YAML", func() {
+		Expect(printOutput(&out, outputYAML, report)).To(Succeed())
+		Expect(out.String()).To(MatchYAML(`
+plugins: [go.kubebuilder.io/v3-alpha]
+changes: [{path: PROJECT, operation: created}]
+commands: [make]
+`))
+	})
+
+	It("should fail for unknown formats", func() {
+		Expect(printOutput(&out, "xml", report)).NotTo(Succeed())
+	})
+})
+
+var _ = Describe("runSubcommand", func() {
+	var (
+		c   cli
+		cfg *internalconfig.Config
+		ctx plugin.Context
+		ran bool
+	)
+
+	run := func() error {
+		ran = true
+		return nil
+	}
+
+	BeforeEach(func() {
+		fs := afero.NewMemMapFs()
+		Expect(afero.WriteFile(fs, internalconfig.DefaultPath, []byte("version: 3-alpha\n"), 0600)).To(Succeed())
+		c = cli{fs: fs, stdout: &bytes.Buffer{}}
+		ctx = plugin.Context{Report: &file.Report{}}
+		ran = false
+
+		var err error
+		cfg, err = internalconfig.LoadFromFs(fs, internalconfig.DefaultPath)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should run holding the project lock", func() {
+		Expect(c.runSubcommand(&cobra.Command{}, cfg, nil, ctx, file.Change{}, func() error {
+			_, err := internalconfig.LockFs(c.fs, internalconfig.DefaultPath, 0)
+			Expect(err).To(HaveOccurred())
+			return run()
+		})).To(Succeed())
+		Expect(ran).To(BeTrue())
+
+		By("releasing the lock afterwards")
+		lock, err := internalconfig.LockFs(c.fs, internalconfig.DefaultPath, 0)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(lock.Unlock()).To(Succeed())
+	})
+
+	It("should fail with a conflict if another command holds the lock", func() {
+		_, err := internalconfig.LockFs(c.fs, internalconfig.DefaultPath, 0)
+		Expect(err).NotTo(HaveOccurred())
+
+		err = c.runSubcommand(&cobra.Command{}, cfg, nil, ctx, file.Change{}, run)
+		Expect(ErrorKindOf(err)).To(Equal(ConflictError))
+		Expect(err.Error()).To(ContainSubstring("locked by another command"))
+		Expect(ran).To(BeFalse())
+	})
+
+	It("should fail with a conflict if the config was modified since it was loaded", func() {
+		Expect(afero.WriteFile(c.fs, internalconfig.DefaultPath, []byte("version: 2\n"), 0600)).To(Succeed())
+
+		err := c.runSubcommand(&cobra.Command{}, cfg, nil, ctx, file.Change{}, run)
+		Expect(ErrorKindOf(err)).To(Equal(ConflictError))
+		Expect(ran).To(BeFalse())
+	})
+
+	It("should not lock in dry-run mode", func() {
+		_, err := internalconfig.LockFs(c.fs, internalconfig.DefaultPath, 0)
+		Expect(err).NotTo(HaveOccurred())
+
+		ctx.DryRun = true
+		Expect(c.runSubcommand(&cobra.Command{}, cfg, nil, ctx, file.Change{}, run)).To(Succeed())
+		Expect(ran).To(BeTrue())
+	})
+
+	It("should report the changes of every plugin sorted by path", func() {
+		ctx.DryRun = true
+		Expect(c.runSubcommand(&cobra.Command{}, cfg, nil, ctx, file.Change{}, func() error {
+			ctx.Report.Add(file.Change{Path: "main.go", Operation: file.InsertedInto})
+			ctx.Report.Add(file.Change{Path: "api/v1/captain_types.go", Operation: file.Created})
+			// Added by another plugin of the chain
+			ctx.Report.Add(file.Change{Path: "main.go", Operation: file.Overwritten})
+			ctx.Report.Add(file.Change{Path: "Makefile", Operation: file.Skipped})
+			return nil
+		})).To(Succeed())
+		Expect(ctx.Report.Changes()).To(Equal([]file.Change{
+			{Path: "Makefile", Operation: file.Skipped},
+			{Path: "api/v1/captain_types.go", Operation: file.Created},
+			{Path: "main.go", Operation: file.InsertedInto},
+			{Path: "main.go", Operation: file.Overwritten},
+		}))
+	})
+})
+
+var _ = Describe("runAndSaveConfig", func() {
+	var (
+		fs  afero.Fs
+		cfg *internalconfig.Config
+		ctx plugin.Context
+	)
+
+	BeforeEach(func() {
+		fs = afero.NewMemMapFs()
+		cfg = internalconfig.NewFs(fs, internalconfig.DefaultPath)
+		cfg.Version = config.Version3Alpha
+		ctx = plugin.Context{Stdout: ioutil.Discard}
+	})
+
+	It("should save the config if the subcommand succeeded", func() {
+		Expect(runAndSaveConfig(cfg, ctx, func() error { return nil }, "msg")).To(Succeed())
+		Expect(afero.Exists(fs, internalconfig.DefaultPath)).To(BeTrue())
+	})
+
+	It("should not save the config if the scaffold failed", func() {
+		err := runAndSaveConfig(cfg, ctx, func() error { return errors.New("scaffold error") }, "msg")
+		Expect(ErrorKindOf(err)).To(Equal(PluginError))
+		Expect(afero.Exists(fs, internalconfig.DefaultPath)).To(BeFalse())
+	})
+
+	It("should save the config if only the post-scaffold step failed", func() {
+		err := runAndSaveConfig(cfg, ctx, func() error {
+			return cmdutil.Run(postScaffoldFailure{}, ctx)
+		}, "msg")
+		Expect(ErrorKindOf(err)).To(Equal(ToolchainError))
+		Expect(afero.Exists(fs, internalconfig.DefaultPath)).To(BeTrue())
+	})
+
+	It("should not save the config in dry-run mode", func() {
+		ctx.DryRun = true
+		Expect(runAndSaveConfig(cfg, ctx, func() error { return nil }, "msg")).To(Succeed())
+		Expect(afero.Exists(fs, internalconfig.DefaultPath)).To(BeFalse())
+	})
+})
+
+var _ = Describe("runECmdFunc", func() {
+	It("should save the config if only the post-scaffold step failed", func() {
+		fs := afero.NewMemMapFs()
+		Expect(afero.WriteFile(fs, internalconfig.DefaultPath, []byte("version: 3-alpha\n"), 0600)).To(Succeed())
+		cfg, err := internalconfig.LoadFromFs(fs, internalconfig.DefaultPath)
+		Expect(err).NotTo(HaveOccurred())
+
+		c := cli{fs: fs, stdout: &bytes.Buffer{}, skipHooks: true}
+		ctx := plugin.Context{Report: &file.Report{}, Stdout: ioutil.Discard}
+		sub := postScaffoldSubcommand{ctx: ctx, cfg: &cfg.Config}
+		err = c.runECmdFunc(cfg, nil, sub, ctx, "msg")(&cobra.Command{}, nil)
+		Expect(ErrorKindOf(err)).To(Equal(ToolchainError))
+
+		saved, err := internalconfig.ReadFromFs(fs, internalconfig.DefaultPath)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(saved.Domain).To(Equal("example.com"))
+	})
+})
+
+// postScaffoldSubcommand is a subcommand that updates the config and fails in the post-scaffold step.
+type postScaffoldSubcommand struct {
+	mockPlugin
+	ctx plugin.Context
+	cfg *config.Config
+}
+
+func (s postScaffoldSubcommand) Run() error {
+	s.cfg.Domain = "example.com"
+	return cmdutil.Run(postScaffoldFailure{}, s.ctx)
+}
+
+// postScaffoldFailure are cmdutil.RunOptions without scaffolder that fail in the post-scaffold step.
+type postScaffoldFailure struct{}
+
+func (postScaffoldFailure) Validate() error                             { return nil }
+func (postScaffoldFailure) GetScaffolder() (scaffold.Scaffolder, error) { return nil, nil }
+func (postScaffoldFailure) PostScaffold() error                         { return errors.New("make failed") }
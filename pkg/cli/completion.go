@@ -0,0 +1,316 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	internalconfig "sigs.k8s.io/kubebuilder/internal/config"
+	"sigs.k8s.io/kubebuilder/pkg/model/config"
+)
+
+// completeCmdName is the hidden command called by the completion scripts. Its
+// arguments are the words typed after the command name, the last one being the
+// word to complete, and it prints the candidates for that word one per line.
+const completeCmdName = "__complete"
+
+// completionFunc returns the values a flag can be completed with. The flags
+// typed so far are passed so that the values can be narrowed down.
+type completionFunc func(flags *pflag.FlagSet) []string
+
+// registerFlagCompletion sets the function used to complete the values of the
+// named flag in fs. Flags that are not defined are ignored.
+func (c cli) registerFlagCompletion(fs *pflag.FlagSet, name string, complete completionFunc) {
+	if flag := fs.Lookup(name); flag != nil {
+		c.flagCompletions[flag] = complete
+	}
+}
+
+func (c cli) newCompletionCmd() *cobra.Command {
+	completionCmd := &cobra.Command{
+		Use:   "completion",
+		Short: "Output shell completion code",
+		Long: fmt.Sprintf(`Output shell completion code for the specified shell (bash, zsh, fish or powershell).
+The shell code must be evaluated to provide interactive completion of %[1]s commands.
+Besides commands and flags, bash, zsh and fish complete the plugin keys and project versions
+available to 'init', and the groups, versions and kinds of the project resources for
+'create webhook'.
+Detailed instructions on how to do this are available at docs/book/src/reference/completion.md
+`, c.commandName),
+		Example: fmt.Sprintf(`To load all completions run:
+$ . <(%[1]s completion bash)
+To configure your shell to load completions for each session add to your .bashrc:
+$ echo -e "\n. <(%[1]s completion bash)" >> ~/.bashrc
+`, c.commandName),
+	}
+	completionCmd.AddCommand(
+		c.newBashCompletionCmd(),
+		c.newZshCompletionCmd(),
+		c.newFishCompletionCmd(),
+		c.newPowerShellCompletionCmd(),
+	)
+	return completionCmd
+}
+
+func (c cli) newBashCompletionCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "bash",
+		Short: "Generate bash completions",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			root := cmd.Root()
+			// Flag values unknown to the static script are completed by the custom function.
+			root.BashCompletionFunction = fmt.Sprintf(bashCustomFunc, root.Name(), completeCmdName)
+			return root.GenBashCompletion(cmd.OutOrStdout())
+		},
+		Example: fmt.Sprintf(`To load completion run:
+$ . <(%[1]s completion bash)
+To configure your bash shell to load completions for each session add to your bashrc:
+$ echo -e "\n. <(%[1]s completion bash)" >> ~/.bashrc
+`, c.commandName),
+	}
+}
+
+func (c cli) newZshCompletionCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "zsh",
+		Short: "Generate zsh completions",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			_, err := fmt.Fprintf(cmd.OutOrStdout(), zshCompletion, cmd.Root().Name(), completeCmdName)
+			return err
+		},
+		Example: fmt.Sprintf(`To load completion run:
+$ . <(%[1]s completion zsh)
+To configure your zsh shell to load completions for each session add to your zshrc:
+$ echo -e "\n. <(%[1]s completion zsh)" >> ~/.zshrc
+`, c.commandName),
+	}
+}
+
+func (c cli) newFishCompletionCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "fish",
+		Short: "Generate fish completions",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			_, err := fmt.Fprintf(cmd.OutOrStdout(), fishCompletion, cmd.Root().Name(), completeCmdName)
+			return err
+		},
+		Example: fmt.Sprintf(`To load completion run:
+$ %[1]s completion fish | source
+To configure your fish shell to load completions for each session run:
+$ %[1]s completion fish > ~/.config/fish/completions/%[1]s.fish
+`, c.commandName),
+	}
+}
+
+func (c cli) newPowerShellCompletionCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "powershell",
+		Short: "Generate powershell completions",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return cmd.Root().GenPowerShellCompletion(cmd.OutOrStdout())
+		},
+		Example: fmt.Sprintf(`To load completion run:
+PS> %[1]s completion powershell | Out-String | Invoke-Expression
+To configure PowerShell to load completions for each session add the above line to your $PROFILE.
+`, c.commandName),
+	}
+}
+
+func (c cli) newCompleteCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:    completeCmdName,
+		Short:  "Print the completion candidates for the given words",
+		Hidden: true,
+		// The words must reach the command as typed, including flags.
+		DisableFlagParsing: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return printCandidates(cmd.OutOrStdout(), c.complete(cmd.Root(), args))
+		},
+	}
+}
+
+// complete returns the candidates for the last element of args, the previous
+// ones being the words already typed after the root command.
+func (c cli) complete(root *cobra.Command, args []string) []string {
+	if len(args) == 0 {
+		return nil
+	}
+	toComplete := args[len(args)-1]
+	cmd, flagArgs, err := root.Find(args[:len(args)-1])
+	if err != nil {
+		return nil
+	}
+	// Parse the flags typed so far, errors are ignored as the words may be incomplete.
+	_ = cmd.ParseFlags(flagArgs)
+
+	var (
+		flag   *pflag.Flag
+		prefix string
+	)
+	switch {
+	case strings.HasPrefix(toComplete, "--") && strings.Contains(toComplete, "="):
+		// --flag=value
+		i := strings.Index(toComplete, "=")
+		if flag = cmd.Flags().Lookup(toComplete[2:i]); flag == nil {
+			return nil
+		}
+		prefix, toComplete = toComplete[:i+1], toComplete[i+1:]
+	case strings.HasPrefix(toComplete, "-"):
+		return completeFlagNames(cmd, toComplete)
+	case len(flagArgs) != 0 && strings.HasPrefix(flagArgs[len(flagArgs)-1], "--"):
+		// --flag value, boolean flags do not take a separate value.
+		previous := flagArgs[len(flagArgs)-1]
+		if flag = cmd.Flags().Lookup(previous[2:]); flag != nil && flag.NoOptDefVal != "" {
+			flag = nil
+		}
+	}
+	if flag == nil {
+		return completeCommandNames(cmd, toComplete)
+	}
+
+	completeFlag, hasCompletion := c.flagCompletions[flag]
+	if !hasCompletion {
+		return nil
+	}
+	// Slice flags are completed one comma-separated element at a time.
+	if i := strings.LastIndex(toComplete, ","); i >= 0 && strings.HasSuffix(flag.Value.Type(), "Slice") {
+		prefix, toComplete = prefix+toComplete[:i+1], toComplete[i+1:]
+	}
+	var candidates []string
+	for _, value := range completeFlag(cmd.Flags()) {
+		if strings.HasPrefix(value, toComplete) {
+			candidates = append(candidates, prefix+value)
+		}
+	}
+	return candidates
+}
+
+// completeCommandNames returns the names of the subcommands of cmd that start with toComplete.
+func completeCommandNames(cmd *cobra.Command, toComplete string) (candidates []string) {
+	for _, subCmd := range cmd.Commands() {
+		if subCmd.IsAvailableCommand() && strings.HasPrefix(subCmd.Name(), toComplete) {
+			candidates = append(candidates, subCmd.Name())
+		}
+	}
+	return candidates
+}
+
+// completeFlagNames returns the long names of the flags of cmd that start with toComplete.
+func completeFlagNames(cmd *cobra.Command, toComplete string) (candidates []string) {
+	cmd.Flags().VisitAll(func(flag *pflag.Flag) {
+		name := "--" + flag.Name
+		if !flag.Hidden && flag.Deprecated == "" && strings.HasPrefix(name, toComplete) {
+			candidates = append(candidates, name)
+		}
+	})
+	return candidates
+}
+
+// completeResources returns a completionFunc for the group, version or kind
+// flags, whose values are taken from the resources of the project config. The
+// group and version flags typed so far restrict the resources being considered.
+func (c cli) completeResources(field string) completionFunc {
+	return func(flags *pflag.FlagSet) []string {
+		cfg, err := internalconfig.ReadFromFs(c.fs, internalconfig.DefaultPath)
+		if err != nil {
+			return nil
+		}
+		return resourceValues(cfg.Resources, flags, field)
+	}
+}
+
+// resourceValues returns the sorted, unique values of field among resources
+// matching the group and version set in flags.
+func resourceValues(resources []config.GVK, flags *pflag.FlagSet, field string) []string {
+	group, _ := flags.GetString(groupFlag)
+	version, _ := flags.GetString(versionFlag)
+
+	valueSet := make(map[string]struct{})
+	for _, resource := range resources {
+		if field != groupFlag && group != "" && resource.Group != group {
+			continue
+		}
+		if field == kindFlag && version != "" && resource.Version != version {
+			continue
+		}
+		switch field {
+		case groupFlag:
+			valueSet[resource.Group] = struct{}{}
+		case versionFlag:
+			valueSet[resource.Version] = struct{}{}
+		case kindFlag:
+			valueSet[resource.Kind] = struct{}{}
+		}
+	}
+	values := make([]string, 0, len(valueSet))
+	for value := range valueSet {
+		values = append(values, value)
+	}
+	sort.Strings(values)
+	return values
+}
+
+// printCandidates writes the completion candidates to w, one per line.
+func printCandidates(w io.Writer, candidates []string) error {
+	for _, candidate := range candidates {
+		if _, err := fmt.Fprintln(w, candidate); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// bashCustomFunc is called by the script generated by cobra when it has no
+// candidates for a word, which includes the values of most flags.
+const bashCustomFunc = `__%[1]s_custom_func()
+{
+    local comp
+    while IFS='' read -r comp; do
+        [[ -n "${comp}" ]] && COMPREPLY+=("${comp}")
+    done < <("${words[0]}" %[2]s "${words[@]:1:$((cword-1))}" "${cur}" 2>/dev/null)
+}
+`
+
+const zshCompletion = `#compdef %[1]s
+
+_%[1]s()
+{
+    local -a candidates
+    candidates=("${(@f)$("${words[1]}" %[2]s "${(@)words[2,$((CURRENT-1))]}" "${words[CURRENT]}" 2>/dev/null)}")
+    candidates=("${(@)candidates:#}")
+    (( ${#candidates} )) || return 1
+    compadd -Q -- "${candidates[@]}"
+}
+
+compdef _%[1]s %[1]s
+`
+
+const fishCompletion = `function __%[1]s_complete
+    set -l words (commandline -opc)
+    set -l program $words[1]
+    set -e words[1]
+    $program %[2]s $words (commandline -ct) 2>/dev/null
+end
+
+complete -c %[1]s -f -a '(__%[1]s_complete)'
+`
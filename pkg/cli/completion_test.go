@@ -0,0 +1,92 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/spf13/pflag"
+
+	"sigs.k8s.io/kubebuilder/pkg/model/config"
+)
+
+var _ = Describe("Completion", func() {
+
+	var (
+		c   CLI
+		err error
+	)
+
+	BeforeEach(func() {
+		p1 := makeAllPlugin("go.example.com", "v1", config.Version2, config.Version3Alpha)
+		p2 := makeAllPlugin("go.test.com", "v1", config.Version3Alpha)
+		c, err = New(WithDefaultPlugins(p1), WithPlugins(p1, p2))
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	complete := func(args ...string) []string {
+		return c.(*cli).complete(c.(*cli).cmd, args)
+	}
+
+	It("should complete command names", func() {
+		Expect(complete("cr")).To(Equal([]string{"create"}))
+		Expect(complete("create", "")).To(Equal([]string{"api", "webhook"}))
+		Expect(complete(completeCmdName)).To(BeEmpty())
+	})
+
+	It("should complete flag names", func() {
+		Expect(complete("init", "--pl")).To(Equal([]string{"--plugins"}))
+		Expect(complete("init", "--project-")).To(Equal([]string{"--project-version"}))
+	})
+
+	It("should complete the values of init flags", func() {
+		By("completing --project-version")
+		Expect(complete("init", "--project-version", "")).To(Equal([]string{"2", "3-alpha"}))
+		Expect(complete("init", "--project-version=3")).To(Equal([]string{"--project-version=3-alpha"}))
+
+		By("completing --plugins one element at a time")
+		Expect(complete("init", "--plugins", "go.t")).To(Equal([]string{"go.test.com/v1"}))
+		Expect(complete("init", "--plugins", "go.test.com/v1,go.e")).
+			To(Equal([]string{"go.test.com/v1,go.example.com/v1"}))
+
+		By("not completing the values of boolean flags")
+		Expect(complete("init", "--dry-run", "")).To(BeEmpty())
+	})
+
+	It("should complete resource flags from the project resources", func() {
+		resources := []config.GVK{
+			{Group: "crew", Version: "v1", Kind: "Captain"},
+			{Group: "crew", Version: "v2", Kind: "FirstMate"},
+			{Group: "ship", Version: "v1", Kind: "Frigate"},
+			{Group: "ship", Version: "v1", Kind: "Frigate"},
+		}
+		fs := pflag.NewFlagSet("webhook", pflag.ContinueOnError)
+		fs.String(groupFlag, "", "")
+		fs.String(versionFlag, "", "")
+		fs.String(kindFlag, "", "")
+
+		Expect(resourceValues(resources, fs, groupFlag)).To(Equal([]string{"crew", "ship"}))
+		Expect(resourceValues(resources, fs, versionFlag)).To(Equal([]string{"v1", "v2"}))
+		Expect(resourceValues(resources, fs, kindFlag)).To(Equal([]string{"Captain", "FirstMate", "Frigate"}))
+
+		Expect(fs.Set(groupFlag, "crew")).To(Succeed())
+		Expect(resourceValues(resources, fs, versionFlag)).To(Equal([]string{"v1", "v2"}))
+		Expect(fs.Set(versionFlag, "v2")).To(Succeed())
+		Expect(resourceValues(resources, fs, kindFlag)).To(Equal([]string{"FirstMate"}))
+	})
+})
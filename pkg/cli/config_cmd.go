@@ -0,0 +1,80 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+)
+
+func (c cli) newConfigCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "Inspect the user configuration",
+		Long: fmt.Sprintf(`Inspect the user configuration.
+
+Flag defaults are read from %s:
+
+  defaults:
+    domain: example.com
+    owner: The Example Authors
+    repo-prefix: github.com/example
+
+and from environment variables named after the flags, such as %sDOMAIN,
+which take precedence over the file. Flags set in the command line always win.
+`, c.userConfigPath, defaultEnvPrefix),
+	}
+	cmd.AddCommand(c.newConfigViewCmd())
+	return cmd
+}
+
+func (c cli) newConfigViewCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "view",
+		Short: "Show the flag defaults set by the user and where they come from",
+		Example: fmt.Sprintf(`  # Show the flag defaults
+  %[1]s config view
+
+  # Show the flag defaults as YAML
+  %[1]s config view --output yaml`, c.commandName),
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if c.output != "" {
+				return printOutput(cmd.OutOrStdout(), c.output, c.flagDefaults)
+			}
+			return printFlagDefaults(cmd.OutOrStdout(), c.flagDefaults)
+		},
+	}
+}
+
+// printFlagDefaults writes a table with a row per flag default to w.
+func printFlagDefaults(w io.Writer, defaults []flagDefault) error {
+	if len(defaults) == 0 {
+		_, err := fmt.Fprintln(w, "No flag defaults are set.")
+		return err
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "FLAG\tVALUE\tSOURCE")
+	for _, d := range defaults {
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", d.Name, d.Value, d.Source)
+	}
+	return tw.Flush()
+}
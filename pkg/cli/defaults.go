@@ -0,0 +1,175 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/afero"
+	"github.com/spf13/pflag"
+
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// userConfigFile is the name of the user config file in the user configuration directory.
+	userConfigFile = "config.yaml"
+	// defaultEnvPrefix prefixes the environment variables setting flag defaults,
+	// ex. KUBEBUILDER_DEFAULT_DOMAIN for --domain.
+	defaultEnvPrefix = "KUBEBUILDER_DEFAULT_"
+	// repoPrefixDefault is not a flag, it sets the default --repo to the prefix
+	// followed by the name of the current directory.
+	repoPrefixDefault = "repo-prefix"
+	// userDefaultAnnotation marks flags whose default value was set by the user,
+	// its value is the source of the default.
+	userDefaultAnnotation = "kubebuilder_user_default"
+)
+
+// baseFlags are parsed before the commands are built, so they cannot have user defaults.
+var baseFlags = map[string]struct{}{
+	helpFlag:           {},
+	projectVersionFlag: {},
+	pluginsFlag:        {},
+	dryRunFlag:         {},
+	yesFlag:            {},
+	noPromptFlag:       {},
+	skipHooksFlag:      {},
+	templateDirFlag:    {},
+	outputFlag:         {},
+}
+
+// userConfig is the content of the user config file.
+type userConfig struct {
+	// Defaults are the default values of the command flags, by flag name.
+	Defaults map[string]string `json:"defaults,omitempty"`
+}
+
+// flagDefault is a flag default value set by the user.
+type flagDefault struct {
+	// Name is the name of the flag.
+	Name string `json:"name"`
+	// Value is the default value of the flag.
+	Value string `json:"value"`
+	// Source is the file or environment variable the value was read from.
+	Source string `json:"source"`
+}
+
+// WithUserDefaults is an Option that reads flag defaults from the user config file,
+// $XDG_CONFIG_HOME/kubebuilder/config.yaml, and from KUBEBUILDER_DEFAULT_* environment
+// variables, which take precedence. Flags set in the command line always win.
+func WithUserDefaults() Option {
+	return func(c *cli) error {
+		c.userDefaults = true
+		return nil
+	}
+}
+
+// loadFlagDefaults returns the flag defaults set in the user config file at
+// configPath of fs and in environ, sorted by name. A missing file is not an error.
+// The repo default derived from the repo prefix ends with the base of wd.
+func loadFlagDefaults(fs afero.Fs, configPath, wd string, environ []string) ([]flagDefault, error) {
+	defaults := make(map[string]flagDefault)
+
+	b, err := afero.ReadFile(fs, configPath)
+	switch {
+	case err == nil:
+		var cfg userConfig
+		if err := yaml.UnmarshalStrict(b, &cfg); err != nil {
+			return nil, fmt.Errorf("failed to read user config %q: %v", configPath, err)
+		}
+		for name, value := range cfg.Defaults {
+			defaults[name] = flagDefault{Name: name, Value: value, Source: configPath}
+		}
+	case !os.IsNotExist(err):
+		return nil, fmt.Errorf("failed to read user config %q: %v", configPath, err)
+	}
+
+	for _, env := range environ {
+		if !strings.HasPrefix(env, defaultEnvPrefix) {
+			continue
+		}
+		i := strings.Index(env, "=")
+		key, value := env[:i], env[i+1:]
+		name := strings.ReplaceAll(strings.ToLower(strings.TrimPrefix(key, defaultEnvPrefix)), "_", "-")
+		if name == "" {
+			continue
+		}
+		defaults[name] = flagDefault{Name: name, Value: value, Source: key}
+	}
+
+	for name, d := range defaults {
+		if _, isBase := baseFlags[name]; isBase {
+			return nil, fmt.Errorf("flag --%s set in %s cannot have a default", name, d.Source)
+		}
+	}
+
+	// A --repo default takes precedence over the repo prefix.
+	if prefix, hasPrefix := defaults[repoPrefixDefault]; hasPrefix {
+		if _, hasRepo := defaults["repo"]; !hasRepo {
+			defaults["repo"] = flagDefault{
+				Name:   "repo",
+				Value:  path.Join(prefix.Value, filepath.Base(wd)),
+				Source: fmt.Sprintf("%s (%s)", prefix.Source, repoPrefixDefault),
+			}
+		}
+	}
+
+	sorted := make([]flagDefault, 0, len(defaults))
+	for _, d := range defaults {
+		sorted = append(sorted, d)
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Name < sorted[j].Name })
+	return sorted, nil
+}
+
+// applyFlagDefaults sets the user defaults of the flags in fs, which are then
+// overridden by the values set in the command line.
+func (c cli) applyFlagDefaults(fs *pflag.FlagSet) error {
+	for _, d := range c.flagDefaults {
+		flag := fs.Lookup(d.Name)
+		if flag == nil {
+			continue
+		}
+
+		var err error
+		if slice, isSlice := flag.Value.(pflag.SliceValue); isSlice {
+			// Setting a slice value appends to it, replace it so that the command line does too.
+			err = slice.Replace(strings.Split(d.Value, ","))
+		} else {
+			err = flag.Value.Set(d.Value)
+		}
+		if err != nil {
+			return fmt.Errorf("invalid default %q for flag --%s set in %s: %v", d.Value, d.Name, d.Source, err)
+		}
+		flag.DefValue = d.Value
+		if err := fs.SetAnnotation(d.Name, userDefaultAnnotation, []string{d.Source}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// hasUserDefault returns true if the default value of flag was set by the user.
+func hasUserDefault(flag *pflag.Flag) bool {
+	_, isSet := flag.Annotations[userDefaultAnnotation]
+	return isSet
+}
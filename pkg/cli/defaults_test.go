@@ -0,0 +1,120 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/spf13/afero"
+	"github.com/spf13/pflag"
+)
+
+var _ = Describe("Flag defaults", func() {
+
+	var (
+		dir        string
+		configPath string
+	)
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "kubebuilder-defaults")
+		Expect(err).NotTo(HaveOccurred())
+		configPath = filepath.Join(dir, userConfigFile)
+	})
+
+	AfterEach(func() {
+		Expect(os.RemoveAll(dir)).To(Succeed())
+	})
+
+	Describe("loadFlagDefaults", func() {
+		It("should merge the user config file and the environment", func() {
+			Expect(ioutil.WriteFile(configPath, []byte(`defaults:
+  domain: example.org
+  owner: The Example Authors
+`), 0644)).To(Succeed())
+
+			defaults, err := loadFlagDefaults(afero.NewOsFs(), configPath, dir, []string{
+				"HOME=/root",
+				"KUBEBUILDER_DEFAULT_DOMAIN=example.com",
+				"KUBEBUILDER_DEFAULT_SKIP_GO_VERSION_CHECK=true",
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(defaults).To(Equal([]flagDefault{
+				{Name: "domain", Value: "example.com", Source: "KUBEBUILDER_DEFAULT_DOMAIN"},
+				{Name: "owner", Value: "The Example Authors", Source: configPath},
+				{Name: "skip-go-version-check", Value: "true", Source: "KUBEBUILDER_DEFAULT_SKIP_GO_VERSION_CHECK"},
+			}))
+		})
+
+		It("should derive the repo default from the repo prefix", func() {
+			defaults, err := loadFlagDefaults(afero.NewOsFs(), configPath, dir, []string{"KUBEBUILDER_DEFAULT_REPO_PREFIX=github.com/example"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(defaults).To(ContainElement(flagDefault{
+				Name:   "repo",
+				Value:  "github.com/example/" + filepath.Base(dir),
+				Source: "KUBEBUILDER_DEFAULT_REPO_PREFIX (repo-prefix)",
+			}))
+		})
+
+		It("should return an error", func() {
+			By("setting a default for a base flag")
+			_, err := loadFlagDefaults(afero.NewOsFs(), configPath, dir, []string{"KUBEBUILDER_DEFAULT_PLUGINS=go/v2"})
+			Expect(err).To(MatchError("flag --plugins set in KUBEBUILDER_DEFAULT_PLUGINS cannot have a default"))
+
+			By("using an unknown field in the user config file")
+			Expect(ioutil.WriteFile(configPath, []byte("domain: example.org\n"), 0644)).To(Succeed())
+			_, err = loadFlagDefaults(afero.NewOsFs(), configPath, dir, nil)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("applyFlagDefaults", func() {
+		It("should set defaults that the command line overrides", func() {
+			c := cli{flagDefaults: []flagDefault{
+				{Name: "domain", Value: "example.org", Source: configPath},
+				{Name: "groups", Value: "crew,ship", Source: configPath},
+				{Name: "unknown", Value: "value", Source: configPath},
+			}}
+			fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+			domain := fs.String("domain", "my.domain", "")
+			groups := fs.StringSlice("groups", nil, "")
+
+			Expect(c.applyFlagDefaults(fs)).To(Succeed())
+			Expect(*domain).To(Equal("example.org"))
+			Expect(*groups).To(Equal([]string{"crew", "ship"}))
+			Expect(fs.Lookup("domain").DefValue).To(Equal("example.org"))
+			Expect(hasUserDefault(fs.Lookup("domain"))).To(BeTrue())
+
+			Expect(fs.Parse([]string{"--domain", "cli.io", "--groups", "sea"})).To(Succeed())
+			Expect(*domain).To(Equal("cli.io"))
+			Expect(*groups).To(Equal([]string{"sea"}))
+		})
+
+		It("should return an error for invalid values", func() {
+			c := cli{flagDefaults: []flagDefault{{Name: "make", Value: "maybe", Source: "KUBEBUILDER_DEFAULT_MAKE"}}}
+			fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+			fs.Bool("make", true, "")
+			Expect(c.applyFlagDefaults(fs)).NotTo(Succeed())
+		})
+	})
+})
@@ -0,0 +1,230 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"bytes"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/spf13/afero"
+
+	internalconfig "sigs.k8s.io/kubebuilder/internal/config"
+	"sigs.k8s.io/kubebuilder/pkg/model/config"
+)
+
+var _ = Describe("delete", func() {
+	const mainGo = `package main
+
+import (
+	"os"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	crewv1 "example.com/crew/api/v1"
+	"example.com/crew/controllers"
+	// +kubebuilder:scaffold:imports
+)
+
+var (
+	scheme   = runtime.NewScheme()
+	setupLog = ctrl.Log.WithName("setup")
+)
+
+func init() {
+	utilruntime.Must(crewv1.AddToScheme(scheme))
+	// +kubebuilder:scaffold:scheme
+}
+
+func main() {
+	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{Scheme: scheme})
+	if err != nil {
+		os.Exit(1)
+	}
+
+	if err = (&controllers.CaptainReconciler{
+		Client: mgr.GetClient(),
+		Log:    ctrl.Log.WithName("controllers").WithName("Captain"),
+		Scheme: mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "Captain")
+		os.Exit(1)
+	}
+	if err = (&crewv1.Captain{}).SetupWebhookWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create webhook", "webhook", "Captain")
+		os.Exit(1)
+	}
+	if err = (&controllers.FirstMateReconciler{
+		Client: mgr.GetClient(),
+		Log:    ctrl.Log.WithName("controllers").WithName("FirstMate"),
+		Scheme: mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "FirstMate")
+		os.Exit(1)
+	}
+	// +kubebuilder:scaffold:builder
+}
+`
+
+	var (
+		dir = filepath.Join(string(filepath.Separator), "projects", "crew")
+
+		fs             afero.Fs
+		stdout, stderr bytes.Buffer
+	)
+
+	BeforeEach(func() {
+		fs = afero.NewMemMapFs()
+		stdout.Reset()
+		stderr.Reset()
+
+		files := map[string]string{
+			"PROJECT": `domain: example.com
+layout: go.kubebuilder.io/v3-alpha
+repo: example.com/crew
+resources:
+- controller: true
+  domain: crew.example.com
+  group: crew
+  kind: Captain
+  path: example.com/crew/api/v1
+  plural: captains
+  scope: Namespaced
+  version: v1
+  webhooks:
+    defaulting: true
+- controller: true
+  domain: crew.example.com
+  group: crew
+  kind: FirstMate
+  path: example.com/crew/api/v1
+  plural: firstmates
+  scope: Namespaced
+  version: v1
+version: 3-alpha
+`,
+			"main.go": mainGo,
+			filepath.Join("config", "crd", "kustomization.yaml"): `resources:
+- bases/crew.example.com_captains.yaml
+- bases/crew.example.com_firstmates.yaml
+# +kubebuilder:scaffold:crdkustomizeresource
+`,
+			filepath.Join("api", "v1", "captain_types.go"):             "package v1\n",
+			filepath.Join("api", "v1", "captain_webhook.go"):           "package v1\n",
+			filepath.Join("api", "v1", "firstmate_types.go"):           "package v1\n",
+			filepath.Join("api", "v1", "groupversion_info.go"):         "package v1\n",
+			filepath.Join("controllers", "captain_controller.go"):      "package controllers\n",
+			filepath.Join("config", "samples", "crew_v1_captain.yaml"): "kind: Captain\n",
+		}
+		for path, content := range files {
+			Expect(afero.WriteFile(fs, filepath.Join(dir, path), []byte(content), 0644)).To(Succeed())
+		}
+	})
+
+	run := func(args ...string) error {
+		return runProject(fs, dir, &stdout, &stderr, args...)
+	}
+
+	readConfig := func() *config.Config {
+		cfg, err := internalconfig.ReadFromFs(afero.NewBasePathFs(fs, dir), internalconfig.DefaultPath)
+		Expect(err).NotTo(HaveOccurred())
+		return cfg
+	}
+
+	exists := func(path ...string) bool {
+		found, err := afero.Exists(fs, filepath.Join(append([]string{dir}, path...)...))
+		Expect(err).NotTo(HaveOccurred())
+		return found
+	}
+
+	It("should delete an API keeping the code shared with other resources", func() {
+		Expect(run("delete", "api", "--group", "crew", "--version", "v1", "--kind", "Captain", "--yes")).To(Succeed())
+
+		Expect(readConfig().Resources).To(HaveLen(1))
+		Expect(readConfig().Resources[0].Kind).To(Equal("FirstMate"))
+
+		Expect(exists("api", "v1", "captain_types.go")).To(BeFalse())
+		Expect(exists("api", "v1", "captain_webhook.go")).To(BeFalse())
+		Expect(exists("controllers", "captain_controller.go")).To(BeFalse())
+		Expect(exists("config", "samples", "crew_v1_captain.yaml")).To(BeFalse())
+		Expect(exists("api", "v1", "groupversion_info.go")).To(BeTrue())
+		Expect(exists("api", "v1", "firstmate_types.go")).To(BeTrue())
+
+		main, err := afero.ReadFile(fs, filepath.Join(dir, "main.go"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(main)).NotTo(ContainSubstring("Captain"))
+		Expect(string(main)).To(ContainSubstring(`crewv1 "example.com/crew/api/v1"`))
+		Expect(string(main)).To(ContainSubstring("utilruntime.Must(crewv1.AddToScheme(scheme))"))
+		Expect(string(main)).To(ContainSubstring("controllers.FirstMateReconciler"))
+		kustomization, err := afero.ReadFile(fs, filepath.Join(dir, "config", "crd", "kustomization.yaml"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(kustomization)).To(Equal(`resources:
+- bases/crew.example.com_firstmates.yaml
+# +kubebuilder:scaffold:crdkustomizeresource
+`))
+
+		By("deleting the last API of the group version")
+		Expect(run("delete", "api", "--group", "crew", "--version", "v1", "--kind", "FirstMate", "--yes")).To(Succeed())
+		Expect(readConfig().Resources).To(BeEmpty())
+		Expect(exists("api", "v1", "groupversion_info.go")).To(BeFalse())
+		main, err = afero.ReadFile(fs, filepath.Join(dir, "main.go"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(main)).NotTo(ContainSubstring("crewv1"))
+		Expect(string(main)).NotTo(ContainSubstring("controllers"))
+		Expect(stdout.String()).To(ContainSubstring("run `make generate manifests`"))
+	})
+
+	It("should delete the webhooks of a resource", func() {
+		Expect(run("delete", "webhook", "--group", "crew", "--version", "v1", "--kind", "Captain", "--yes")).To(Succeed())
+
+		res, found := readConfig().GetResource(config.GVK{Group: "crew", Version: "v1", Kind: "Captain"})
+		Expect(found).To(BeTrue())
+		Expect(res.Webhooks).To(BeNil())
+		Expect(exists("api", "v1", "captain_webhook.go")).To(BeFalse())
+		Expect(exists("api", "v1", "captain_types.go")).To(BeTrue())
+		main, err := afero.ReadFile(fs, filepath.Join(dir, "main.go"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(main)).NotTo(ContainSubstring("SetupWebhookWithManager"))
+		Expect(string(main)).To(ContainSubstring("controllers.CaptainReconciler"))
+	})
+
+	It("should not delete anything in dry-run mode", func() {
+		Expect(run("delete", "api", "--group", "crew", "--version", "v1", "--kind", "Captain", "--dry-run")).To(Succeed())
+
+		Expect(readConfig().Resources).To(HaveLen(2))
+		Expect(exists("api", "v1", "captain_types.go")).To(BeTrue())
+		Expect(stdout.String()).To(ContainSubstring("  deleted        api/v1/captain_types.go\n"))
+		Expect(stdout.String()).To(ContainSubstring("  removed from   main.go\n"))
+	})
+
+	It("should require a confirmation", func() {
+		err := run("delete", "api", "--group", "crew", "--version", "v1", "--kind", "Captain", "--no-prompt")
+		Expect(ExitCode(err)).To(Equal(2))
+		Expect(stderr.String()).To(ContainSubstring("--yes"))
+		Expect(exists("api", "v1", "captain_types.go")).To(BeTrue())
+	})
+
+	It("should fail for resources which are not tracked", func() {
+		err := run("delete", "api", "--group", "crew", "--version", "v1", "--kind", "Admiral", "--yes")
+		Expect(ExitCode(err)).To(Equal(2))
+		Expect(stderr.String()).To(ContainSubstring("is not tracked in the project"))
+	})
+})
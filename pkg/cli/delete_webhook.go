@@ -0,0 +1,97 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli // nolint:dupl
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"sigs.k8s.io/kubebuilder/internal/config"
+	"sigs.k8s.io/kubebuilder/pkg/plugin"
+)
+
+func (c *cli) newDeleteWebhookCmd() *cobra.Command {
+	ctx := c.newDeleteWebhookContext()
+	cmd := &cobra.Command{
+		Use:     "webhook",
+		Short:   "Delete the webhooks of an API resource",
+		Long:    ctx.Description,
+		Example: ctx.Examples,
+		RunE: errCmdFunc(
+			fmt.Errorf("webhook subcommand requires an existing project"),
+		),
+	}
+
+	// Lookup the plugin for projectVersion and bind it to the command.
+	c.bindDeleteWebhook(ctx, cmd)
+	return cmd
+}
+
+func (c cli) newDeleteWebhookContext() plugin.Context {
+	ctx := c.newContext()
+	ctx.Description = `Delete the webhooks of an API resource.
+`
+	if !c.configured {
+		ctx.Description = fmt.Sprintf("%s\n%s", ctx.Description, runInProjectRootMsg)
+	}
+	return ctx
+}
+
+func (c cli) bindDeleteWebhook(ctx plugin.Context, cmd *cobra.Command) {
+	var (
+		keys        []string
+		subcommands []plugin.GenericSubcommand
+	)
+	for _, p := range c.resolvedPlugins {
+		// Getters return nil if the plugin does not implement the subcommand.
+		if getter, isGetter := p.(plugin.DeleteWebhookPluginGetter); isGetter {
+			if sub := getter.GetDeleteWebhookPlugin(); sub != nil {
+				keys = append(keys, plugin.KeyFor(p))
+				subcommands = append(subcommands, sub)
+			}
+		}
+	}
+
+	cfg, err := config.LoadInitializedFs(c.fs)
+	if err != nil {
+		cmdErr(cmd, err)
+		return
+	}
+
+	if len(subcommands) == 0 {
+		err := fmt.Errorf("layout plugin %q does not support a webhook deletion plugin", cfg.Layout)
+		cmdErr(cmd, err)
+		return
+	}
+
+	deleteWebhook := newSubcommandChain(keys, subcommands)
+	deleteWebhook.InjectConfig(&cfg.Config)
+	deleteWebhook.BindFlags(cmd.Flags())
+	if err = c.applyFlagDefaults(cmd.Flags()); err != nil {
+		cmdErr(cmd, err)
+		return
+	}
+	for _, flag := range []string{groupFlag, versionFlag, kindFlag} {
+		c.registerFlagCompletion(cmd.Flags(), flag, c.completeResources(flag))
+	}
+	deleteWebhook.UpdateContext(&ctx)
+	cmd.Long = ctx.Description
+	cmd.Example = ctx.Examples
+	cmd.RunE = c.runECmdFunc(cfg, keys, deleteWebhook, ctx,
+		fmt.Sprintf("failed to delete webhook with version %q", c.projectVersion))
+}
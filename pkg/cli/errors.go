@@ -0,0 +1,178 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"sigs.k8s.io/kubebuilder/internal/cmdutil"
+	"sigs.k8s.io/kubebuilder/pkg/model/config"
+	"sigs.k8s.io/kubebuilder/pkg/model/file"
+)
+
+// ErrorKind classifies the errors returned by the cli, each kind has its own exit code.
+type ErrorKind string
+
+const (
+	// InternalError is an unexpected failure, ex. the project config could not be saved.
+	InternalError ErrorKind = "internal"
+	// UsageError is caused by the user input, ex. an unknown flag or an invalid resource.
+	UsageError ErrorKind = "usage"
+	// ConflictError is caused by the project state, ex. a file that already exists.
+	ConflictError ErrorKind = "conflict"
+	// PluginError is a failure of a plugin while scaffolding.
+	PluginError ErrorKind = "plugin"
+	// ToolchainError is a failure of a command run after scaffolding, ex. make, or of a hook.
+	ToolchainError ErrorKind = "toolchain"
+)
+
+// exitCodes are the exit codes of each kind of error. Unclassified errors exit with 1,
+// like internal errors.
+var exitCodes = map[ErrorKind]int{
+	InternalError:  1,
+	UsageError:     2,
+	ConflictError:  3,
+	PluginError:    4,
+	ToolchainError: 5,
+}
+
+// Error is an error returned by the cli, classified by its kind.
+type Error struct {
+	Kind ErrorKind
+	Err  error
+}
+
+// Error implements error interface
+func (e *Error) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap implements Wrapper interface
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// ExitCode returns the exit code of the process for the kind of e.
+func (e *Error) ExitCode() int {
+	if code, known := exitCodes[e.Kind]; known {
+		return code
+	}
+	return 1
+}
+
+// newError wraps err as an error of the given kind, unless it was already classified.
+func newError(kind ErrorKind, err error) error {
+	if err == nil {
+		return nil
+	}
+	var cliErr *Error
+	if errors.As(err, &cliErr) {
+		return err
+	}
+	return &Error{Kind: kind, Err: err}
+}
+
+// newRunError classifies an error returned by running a plugin subcommand by the step it failed at,
+// errors returned while scaffolding are plugin errors unless a file already existed.
+func newRunError(err error) error {
+	switch {
+	case cmdutil.IsPostScaffoldError(err):
+		return newError(ToolchainError, err)
+	case cmdutil.IsValidateError(err), file.IsValidateError(err):
+		return newError(UsageError, err)
+	case errors.Is(err, os.ErrExist):
+		return newError(ConflictError, err)
+	default:
+		return newError(PluginError, err)
+	}
+}
+
+// ErrorKindOf returns the kind of err, InternalError if it was not classified by the cli.
+func ErrorKindOf(err error) ErrorKind {
+	var cliErr *Error
+	if errors.As(err, &cliErr) {
+		return cliErr.Kind
+	}
+	return InternalError
+}
+
+// ExitCode returns the exit code of the process for err: 0 if err is nil, the exit code
+// of its kind if it was returned by the cli and 1 otherwise.
+func ExitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	var cliErr *Error
+	if errors.As(err, &cliErr) {
+		return cliErr.ExitCode()
+	}
+	return 1
+}
+
+// errorReport is the machine-readable error printed when --output is set.
+type errorReport struct {
+	Error errorReportDetails `json:"error"`
+}
+
+type errorReportDetails struct {
+	// Kind is the kind of the error.
+	Kind ErrorKind `json:"kind"`
+	// Message describes the error.
+	Message string `json:"message"`
+	// ExitCode is the exit code of the process for the error.
+	ExitCode int `json:"exitCode"`
+	// Fields are the invalid fields of a project config, if the error is caused by them.
+	Fields []config.FieldError `json:"fields,omitempty"`
+}
+
+// invalidConfigError is returned when a project config does not match its schema.
+type invalidConfigError struct {
+	path string
+	errs []config.FieldError
+}
+
+// Error implements error
+func (e invalidConfigError) Error() string {
+	return fmt.Sprintf("%s has %d invalid fields", e.path, len(e.errs))
+}
+
+// PrintError writes err to w in the format used by the cli, so that programs embedding
+// it can report the errors returned by New the same way as the errors printed by Run.
+func PrintError(w io.Writer, err error) {
+	fmt.Fprintf(w, "Error: %v\n", err)
+}
+
+// printError writes err to w in the given output format, or as text if output is empty.
+func printError(w io.Writer, output string, err error) error {
+	if output == "" {
+		PrintError(w, err)
+		return nil
+	}
+	details := errorReportDetails{
+		Kind:     ErrorKindOf(err),
+		Message:  err.Error(),
+		ExitCode: ExitCode(err),
+	}
+	var invalidConfig invalidConfigError
+	if errors.As(err, &invalidConfig) {
+		details.Fields = invalidConfig.errs
+	}
+	return printOutput(w, output, errorReport{Error: details})
+}
@@ -0,0 +1,109 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"sigs.k8s.io/kubebuilder/internal/cmdutil"
+	"sigs.k8s.io/kubebuilder/pkg/model/file"
+	"sigs.k8s.io/kubebuilder/pkg/plugin"
+	"sigs.k8s.io/kubebuilder/pkg/plugin/scaffold"
+)
+
+// failingRunOptions implements cmdutil.RunOptions failing at the given step.
+type failingRunOptions struct {
+	validateErr, postScaffoldErr error
+}
+
+func (o failingRunOptions) Validate() error                             { return o.validateErr }
+func (o failingRunOptions) GetScaffolder() (scaffold.Scaffolder, error) { return nil, nil }
+func (o failingRunOptions) PostScaffold() error                         { return o.postScaffoldErr }
+
+var _ = Describe("Errors", func() {
+	var (
+		failed = errors.New("failed")
+		ctx    = plugin.Context{Stdout: &bytes.Buffer{}}
+	)
+
+	Describe("newRunError", func() {
+		It("should classify the errors by the step they were returned at", func() {
+			By("failing to validate")
+			err := newRunError(cmdutil.Run(failingRunOptions{validateErr: failed}, ctx))
+			Expect(ErrorKindOf(err)).To(Equal(UsageError))
+			Expect(ExitCode(err)).To(Equal(2))
+
+			By("failing to validate a template")
+			err = newRunError(fmt.Errorf("wrapped: %w", file.NewValidateError(failed)))
+			Expect(ErrorKindOf(err)).To(Equal(UsageError))
+
+			By("failing to create an existing file")
+			err = newRunError(fmt.Errorf("wrapped: %w", &os.PathError{Op: "open", Path: "main.go", Err: os.ErrExist}))
+			Expect(ErrorKindOf(err)).To(Equal(ConflictError))
+			Expect(ExitCode(err)).To(Equal(3))
+
+			By("failing to scaffold")
+			err = newRunError(failed)
+			Expect(ErrorKindOf(err)).To(Equal(PluginError))
+			Expect(ExitCode(err)).To(Equal(4))
+
+			By("failing to run a command after scaffolding")
+			err = newRunError(cmdutil.Run(failingRunOptions{postScaffoldErr: failed}, ctx))
+			Expect(ErrorKindOf(err)).To(Equal(ToolchainError))
+			Expect(ExitCode(err)).To(Equal(5))
+			Expect(err).To(MatchError("failed"))
+			Expect(errors.Is(err, failed)).To(BeTrue())
+		})
+
+		It("should keep the kind of classified errors", func() {
+			err := newRunError(fmt.Errorf("wrapped: %w", newError(ToolchainError, failed)))
+			Expect(ErrorKindOf(err)).To(Equal(ToolchainError))
+		})
+	})
+
+	Describe("ExitCode", func() {
+		It("should return 0 for nil and 1 for unclassified errors", func() {
+			Expect(ExitCode(nil)).To(Equal(0))
+			Expect(ExitCode(failed)).To(Equal(1))
+			Expect(ErrorKindOf(failed)).To(Equal(InternalError))
+		})
+	})
+
+	Describe("printError", func() {
+		var out bytes.Buffer
+
+		BeforeEach(func() {
+			out.Reset()
+		})
+
+		It("should print the error as text", func() {
+			Expect(printError(&out, "", newError(UsageError, failed))).To(Succeed())
+			Expect(out.String()).To(Equal("Error: failed\n"))
+		})
+
+		It("should print the error as JSON", func() {
+			Expect(printError(&out, outputJSON, newError(UsageError, failed))).To(Succeed())
+			Expect(out.String()).To(MatchJSON(`{"error": {"kind": "usage", "message": "failed", "exitCode": 2}}`))
+		})
+	})
+})
@@ -0,0 +1,118 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"sigs.k8s.io/kubebuilder/pkg/model/config"
+	"sigs.k8s.io/kubebuilder/pkg/model/file"
+	"sigs.k8s.io/kubebuilder/pkg/plugin"
+)
+
+const skipHooksFlag = "skip-hooks"
+
+// Hook stages.
+const (
+	preHook  = "pre"
+	postHook = "post"
+)
+
+// Environment variables describing the running command to its hooks.
+const (
+	// hookCommandEnv is the name of the command, ex. "create api".
+	hookCommandEnv = "KUBEBUILDER_COMMAND"
+	// hookStageEnv is the stage of the hook, "pre" or "post".
+	hookStageEnv = "KUBEBUILDER_HOOK"
+	// hookGroupEnv, hookVersionEnv and hookKindEnv are the resource set in the command line, if any.
+	hookGroupEnv   = "KUBEBUILDER_GROUP"
+	hookVersionEnv = "KUBEBUILDER_VERSION"
+	hookKindEnv    = "KUBEBUILDER_KIND"
+	// hookChangedFilesEnv are the newline-separated paths of the files changed by the command,
+	// only set for post hooks.
+	hookChangedFilesEnv = "KUBEBUILDER_CHANGED_FILES"
+)
+
+// runWithHooks calls run, which must run a subcommand and save its config, between the
+// pre and post hooks declared in cfg for cmd. Post hooks are looked up after run, as the
+// subcommand may have modified the config. Hooks are skipped in dry-run mode and when
+// --skip-hooks is set.
+func (c cli) runWithHooks(
+	cmd *cobra.Command,
+	cfg *config.Config,
+	ctx plugin.Context,
+	configChange file.Change,
+	run func() error) error {
+	if ctx.DryRun || c.skipHooks {
+		return run()
+	}
+
+	command := strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name()+" ")
+	env := append(append([]string{}, c.environ...), hookCommandEnv+"="+command)
+	for _, resourceEnv := range []struct{ flag, name string }{
+		{groupFlag, hookGroupEnv},
+		{versionFlag, hookVersionEnv},
+		{kindFlag, hookKindEnv},
+	} {
+		if flag := cmd.Flags().Lookup(resourceEnv.flag); flag != nil {
+			env = append(env, resourceEnv.name+"="+flag.Value.String())
+		}
+	}
+
+	if err := runHooks(ctx, command, preHook, cfg.Hooks[command].Pre, env); err != nil {
+		return err
+	}
+
+	if err := run(); err != nil {
+		return err
+	}
+
+	var changed []string
+	for _, change := range append(ctx.Report.Changes(), configChange) {
+		if change.Operation != file.Skipped {
+			changed = append(changed, change.Path)
+		}
+	}
+	env = append(env, hookChangedFilesEnv+"="+strings.Join(changed, "\n"))
+	return runHooks(ctx, command, postHook, cfg.Hooks[command].Post, env)
+}
+
+// runHooks runs each hook with sh in the working directory of ctx, stopping at the first
+// one that fails. Post hooks are recorded in the report of ctx like any other command run
+// after scaffolding. env is the environment of the hooks, besides the stage.
+func runHooks(ctx plugin.Context, command, stage string, hooks, env []string) error {
+	for _, hook := range hooks {
+		fmt.Fprintf(ctx.Stdout, "running %s %s hook:\n$ %s\n", stage, command, hook)
+		if stage == postHook {
+			ctx.Report.AddCommand(hook)
+		}
+
+		h := exec.Command("sh", "-c", hook) //nolint:gosec
+		h.Dir = ctx.WorkingDirectory
+		h.Stdout = ctx.Stdout
+		h.Stderr = ctx.Stderr
+		h.Env = append(append([]string{}, env...), hookStageEnv+"="+stage)
+		if err := h.Run(); err != nil {
+			return newError(ToolchainError, fmt.Errorf("%s hook %q of %q failed: %v", stage, hook, command, err))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,175 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+
+	internalconfig "sigs.k8s.io/kubebuilder/internal/config"
+	"sigs.k8s.io/kubebuilder/pkg/model/config"
+	"sigs.k8s.io/kubebuilder/pkg/model/file"
+	"sigs.k8s.io/kubebuilder/pkg/plugin"
+	pluginv2 "sigs.k8s.io/kubebuilder/pkg/plugin/v2"
+)
+
+var _ = Describe("runWithHooks", func() {
+
+	var (
+		dir          string
+		out          string
+		cmd          *cobra.Command
+		cfg          *config.Config
+		ctx          plugin.Context
+		configChange file.Change
+		ran          bool
+		run          func() error
+	)
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "kubebuilder-hooks")
+		Expect(err).NotTo(HaveOccurred())
+		out = filepath.Join(dir, "out")
+
+		rootCmd := &cobra.Command{Use: "kubebuilder"}
+		createCmd := &cobra.Command{Use: "create"}
+		cmd = &cobra.Command{Use: "api"}
+		cmd.Flags().String(groupFlag, "crew", "")
+		cmd.Flags().String(versionFlag, "v1", "")
+		cmd.Flags().String(kindFlag, "Captain", "")
+		rootCmd.AddCommand(createCmd)
+		createCmd.AddCommand(cmd)
+
+		cfg = &config.Config{Hooks: config.Hooks{
+			"create api": {
+				Pre:  []string{`echo "$KUBEBUILDER_HOOK $KUBEBUILDER_COMMAND $KUBEBUILDER_GROUP" >> ` + out},
+				Post: []string{`echo "$KUBEBUILDER_HOOK $KUBEBUILDER_KIND $KUBEBUILDER_CHANGED_FILES" >> ` + out},
+			},
+		}}
+		ctx = plugin.Context{Report: &file.Report{}, Stdout: GinkgoWriter, Stderr: GinkgoWriter, WorkingDirectory: dir}
+		ctx.Report.Add(file.Change{Path: "main.go", Operation: file.Overwritten})
+		ctx.Report.Add(file.Change{Path: "Makefile", Operation: file.Skipped})
+		configChange = file.Change{Path: "PROJECT", Operation: file.Overwritten}
+		ran = false
+		run = func() error {
+			ran = true
+			return nil
+		}
+	})
+
+	AfterEach(func() {
+		Expect(os.RemoveAll(dir)).To(Succeed())
+	})
+
+	readOut := func() string {
+		b, err := ioutil.ReadFile(out)
+		if os.IsNotExist(err) {
+			return ""
+		}
+		Expect(err).NotTo(HaveOccurred())
+		return string(b)
+	}
+
+	It("should run the hooks of the command around run", func() {
+		Expect(cli{}.runWithHooks(cmd, cfg, ctx, configChange, run)).To(Succeed())
+		Expect(ran).To(BeTrue())
+		Expect(readOut()).To(Equal("pre create api crew\npost Captain main.go\nPROJECT\n"))
+		Expect(ctx.Report.Commands()).To(Equal(cfg.Hooks["create api"].Post))
+	})
+
+	It("should not run the hooks", func() {
+		By("setting --skip-hooks")
+		Expect(cli{skipHooks: true}.runWithHooks(cmd, cfg, ctx, configChange, run)).To(Succeed())
+		Expect(ran).To(BeTrue())
+		Expect(readOut()).To(BeEmpty())
+
+		By("running in dry-run mode")
+		ctx.DryRun = true
+		Expect(cli{}.runWithHooks(cmd, cfg, ctx, configChange, run)).To(Succeed())
+		Expect(readOut()).To(BeEmpty())
+
+		By("failing to run")
+		ctx.DryRun = false
+		err := cli{}.runWithHooks(cmd, cfg, ctx, configChange, func() error { return errors.New("failed") })
+		Expect(err).To(MatchError("failed"))
+		Expect(readOut()).To(Equal("pre create api crew\n"))
+	})
+
+	It("should abort if a hook fails", func() {
+		cfg.Hooks["create api"] = config.CommandHooks{Pre: []string{"exit 3"}}
+		err := cli{}.runWithHooks(cmd, cfg, ctx, configChange, run)
+		Expect(err).To(MatchError(`pre hook "exit 3" of "create api" failed: exit status 3`))
+		Expect(ran).To(BeFalse())
+	})
+})
+
+var _ = Describe("hooks calling the cli", func() {
+
+	var dir string
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "kubebuilder-hooks")
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(ioutil.WriteFile(filepath.Join(dir, "PROJECT"), []byte(`domain: example.com
+repo: example.com/crew
+version: "2"
+hooks:
+  edit:
+    post:
+    - '"$`+testCLIEnv+`" edit --multigroup=false --skip-hooks'
+`), 0644)).To(Succeed())
+		Expect(ioutil.WriteFile(filepath.Join(dir, "Dockerfile"), []byte("COPY api/ api/\n"), 0644)).To(Succeed())
+	})
+
+	AfterEach(func() {
+		Expect(os.RemoveAll(dir)).To(Succeed())
+	})
+
+	It("should not hold the project lock while the hooks run", func() {
+		executable, err := os.Executable()
+		Expect(err).NotTo(HaveOccurred())
+
+		c, err := New(
+			WithPlugins(&pluginv2.Plugin{}),
+			WithDefaultPlugins(&pluginv2.Plugin{}),
+			WithArgs("edit", "--multigroup"),
+			WithIOStreams(&bytes.Buffer{}, GinkgoWriter, GinkgoWriter),
+			WithWorkingDirectory(dir),
+			WithEnvironment(append(os.Environ(), testCLIEnv+"="+executable)...),
+		)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(c.Run()).To(Succeed())
+
+		By("keeping the changes of the command run by the hook")
+		cfg, err := internalconfig.ReadFromFs(afero.NewOsFs(), filepath.Join(dir, "PROJECT"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cfg.MultiGroup).To(BeFalse())
+		Expect(ioutil.ReadFile(filepath.Join(dir, "Dockerfile"))).To(Equal([]byte("COPY api/ api/\n")))
+	})
+})
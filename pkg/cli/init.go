@@ -17,17 +17,19 @@ limitations under the License.
 package cli
 
 import (
+	"errors"
 	"fmt"
-	"log"
 	"os"
 	"sort"
 	"strconv"
 	"strings"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 
 	internalconfig "sigs.k8s.io/kubebuilder/internal/config"
 	"sigs.k8s.io/kubebuilder/pkg/model/config"
+	"sigs.k8s.io/kubebuilder/pkg/model/file"
 	"sigs.k8s.io/kubebuilder/pkg/plugin"
 )
 
@@ -48,9 +50,15 @@ func (c *cli) newInitCmd() *cobra.Command {
 	// The --plugins flag can only be called to init projects v2+.
 	if c.projectVersion != config.Version1 {
 		cmd.Flags().StringSlice(pluginsFlag, nil,
-			"Name and optionally version of the plugin to initialize the project with. "+
+			"Names and optionally versions of the plugins to initialize the project with, in the order they run. "+
 				fmt.Sprintf("Available plugins: (%s)", strings.Join(c.getAvailablePlugins(), ", ")))
 	}
+	c.registerFlagCompletion(cmd.Flags(), projectVersionFlag, func(*pflag.FlagSet) []string {
+		return c.availableProjectVersions()
+	})
+	c.registerFlagCompletion(cmd.Flags(), pluginsFlag, func(*pflag.FlagSet) []string {
+		return c.availablePluginKeys()
+	})
 
 	// If only the help flag was set, return the command as is.
 	if c.doGenericHelp {
@@ -63,14 +71,13 @@ func (c *cli) newInitCmd() *cobra.Command {
 }
 
 func (c cli) newInitContext() plugin.Context {
-	return plugin.Context{
-		CommandName: c.commandName,
-		Description: `Initialize a new project.
+	ctx := c.newContext()
+	ctx.Description = `Initialize a new project.
 
 For further help about a specific project version, set --project-version.
-`,
-		Examples: c.getInitHelpExamples(),
-	}
+`
+	ctx.Examples = c.getInitHelpExamples()
+	return ctx
 }
 
 func (c cli) getInitHelpExamples() string {
@@ -86,26 +93,33 @@ func (c cli) getInitHelpExamples() string {
 	return strings.TrimSuffix(sb.String(), "\n\n")
 }
 
-func (c cli) getAvailableProjectVersions() (projectVersions []string) {
-	versionSet := make(map[string]struct{})
+func (c cli) getAvailableProjectVersions() []string {
+	return quoteAll(c.availableProjectVersions())
+}
+
+func (c cli) getAvailablePlugins() []string {
+	return quoteAll(c.availablePluginKeys())
+}
+
+// availableProjectVersions returns the sorted project versions which have at
+// least one non-deprecated plugin.
+func (c cli) availableProjectVersions() (projectVersions []string) {
 	for version, versionedPlugins := range c.pluginsFromOptions {
 		for _, p := range versionedPlugins {
 			// If there's at least one non-deprecated plugin per version, that
 			// version is "available".
 			if _, isDeprecated := p.(plugin.Deprecated); !isDeprecated {
-				versionSet[version] = struct{}{}
+				projectVersions = append(projectVersions, version)
 				break
 			}
 		}
 	}
-	for version := range versionSet {
-		projectVersions = append(projectVersions, strconv.Quote(version))
-	}
 	sort.Strings(projectVersions)
 	return projectVersions
 }
 
-func (c cli) getAvailablePlugins() (pluginKeys []string) {
+// availablePluginKeys returns the sorted keys of the non-deprecated plugins.
+func (c cli) availablePluginKeys() (pluginKeys []string) {
 	keySet := make(map[string]struct{})
 	for _, versionedPlugins := range c.pluginsFromOptions {
 		for _, p := range versionedPlugins {
@@ -116,56 +130,75 @@ func (c cli) getAvailablePlugins() (pluginKeys []string) {
 		}
 	}
 	for key := range keySet {
-		pluginKeys = append(pluginKeys, strconv.Quote(key))
+		pluginKeys = append(pluginKeys, key)
 	}
 	sort.Strings(pluginKeys)
 	return pluginKeys
 }
 
+func quoteAll(values []string) []string {
+	quoted := make([]string, 0, len(values))
+	for _, value := range values {
+		quoted = append(quoted, strconv.Quote(value))
+	}
+	return quoted
+}
+
 func (c cli) bindInit(ctx plugin.Context, cmd *cobra.Command) {
-	var getter plugin.InitPluginGetter
+	var (
+		keys        []string
+		subcommands []plugin.GenericSubcommand
+	)
 	for _, p := range c.resolvedPlugins {
-		tmpGetter, isGetter := p.(plugin.InitPluginGetter)
-		if isGetter {
-			if getter != nil {
-				err := fmt.Errorf("duplicate initialization plugins for project version %q (%s, %s), "+
-					"use a more specific plugin key", c.projectVersion, plugin.KeyFor(getter), plugin.KeyFor(p))
-				cmdErrNoHelp(cmd, err)
-				return
+		// Getters return nil if the plugin does not implement the subcommand.
+		if getter, isGetter := p.(plugin.InitPluginGetter); isGetter {
+			if sub := getter.GetInitPlugin(); sub != nil {
+				keys = append(keys, plugin.KeyFor(p))
+				subcommands = append(subcommands, sub)
 			}
-			getter = tmpGetter
 		}
 	}
-	if getter == nil {
+	if len(subcommands) == 0 {
 		var err error
-		if c.cliPluginKey == "" {
+		if len(c.cliPluginKeys) == 0 {
 			err = fmt.Errorf("project version %q does not support an initialization plugin", c.projectVersion)
 		} else {
-			err = fmt.Errorf("plugin %q does not support an initialization plugin", c.cliPluginKey)
+			err = fmt.Errorf("plugins %q do not support an initialization plugin", c.cliPluginKeys)
 		}
 		cmdErrNoHelp(cmd, err)
 		return
 	}
 
-	cfg := internalconfig.New(internalconfig.DefaultPath)
+	cfg := internalconfig.NewFs(c.fs, internalconfig.DefaultPath)
 	cfg.Version = c.projectVersion
+	// v3 project configs get a 'layout' value with every plugin of the chain.
+	if cfg.IsV3() {
+		for _, p := range c.resolvedPlugins {
+			cfg.Layout = append(cfg.Layout, plugin.KeyFor(p))
+		}
+	}
 
-	init := getter.GetInitPlugin()
+	init := newSubcommandChain(keys, subcommands)
 	init.InjectConfig(&cfg.Config)
 	init.BindFlags(cmd.Flags())
+	if err := c.applyFlagDefaults(cmd.Flags()); err != nil {
+		cmdErr(cmd, err)
+		return
+	}
 	init.UpdateContext(&ctx)
 	cmd.Long = ctx.Description
 	cmd.Example = ctx.Examples
-	cmd.RunE = func(*cobra.Command, []string) error {
+	cmd.RunE = func(cmd *cobra.Command, _ []string) error {
 		// Check if a config is initialized in the command runner so the check
 		// doesn't erroneously fail other commands used in initialized projects.
-		_, err := internalconfig.Read()
+		_, err := internalconfig.ReadFromFs(c.fs, internalconfig.DefaultPath)
 		if err == nil || os.IsExist(err) {
-			log.Fatal("config already initialized")
-		}
-		if err := init.Run(); err != nil {
-			return fmt.Errorf("failed to initialize project with version %q: %v", c.projectVersion, err)
+			return newError(ConflictError, errors.New("config already initialized"))
 		}
-		return cfg.Save()
+		configChange := file.Change{Path: cfg.Path(), Operation: file.Created}
+		return c.runSubcommand(cmd, cfg, keys, ctx, configChange, func() error {
+			return runAndSaveConfig(cfg, ctx, init.Run,
+				fmt.Sprintf("failed to initialize project with version %q", c.projectVersion))
+		})
 	}
 }
@@ -0,0 +1,116 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"bytes"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/spf13/afero"
+
+	internalconfig "sigs.k8s.io/kubebuilder/internal/config"
+	"sigs.k8s.io/kubebuilder/pkg/model/config"
+)
+
+var _ = Describe("alpha migrate", func() {
+	var (
+		dir = filepath.Join(string(filepath.Separator), "projects", "crew")
+
+		fs             afero.Fs
+		stdout, stderr bytes.Buffer
+	)
+
+	BeforeEach(func() {
+		fs = afero.NewMemMapFs()
+		stdout.Reset()
+		stderr.Reset()
+
+		files := map[string]string{
+			"PROJECT": `domain: example.com
+repo: example.com/crew
+resources:
+- group: ship
+  kind: Captain
+  version: v1
+version: "2"
+`,
+			"Dockerfile": "FROM golang:1.13 as builder\nUSER nonroot:nonroot\n",
+			"main.go":    "// +kubebuilder:scaffold:imports\n// +kubebuilder:scaffold:builder\n",
+			filepath.Join("api", "v1", "captain_types.go"): "// +kubebuilder:resource:scope=Cluster\n",
+			filepath.Join("controllers", "captain_controller.go"): "// +kubebuilder:rbac:groups=ship.example.com," +
+				"resources=captains/status,verbs=get;update;patch\n",
+		}
+		for path, content := range files {
+			Expect(afero.WriteFile(fs, filepath.Join(dir, path), []byte(content), 0644)).To(Succeed())
+		}
+	})
+
+	run := func(args ...string) error {
+		return runProject(fs, dir, &stdout, &stderr, append([]string{"alpha", "migrate"}, args...)...)
+	}
+
+	It("should migrate a version 2 project and report the manual steps", func() {
+		Expect(run()).To(Succeed())
+
+		cfg, err := internalconfig.ReadFromFs(afero.NewBasePathFs(fs, dir), internalconfig.DefaultPath)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cfg.Version).To(Equal(config.Version3Alpha))
+		Expect(cfg.Layout).To(Equal(config.Layout{"go.kubebuilder.io/v3-alpha"}))
+		Expect(cfg.ProjectName).To(Equal("crew"))
+		Expect(cfg.Resources).To(Equal([]config.GVK{{
+			Group:      "ship",
+			Version:    "v1",
+			Kind:       "Captain",
+			Plural:     "captains",
+			Scope:      config.ClusterScope,
+			Domain:     "ship.example.com",
+			Path:       "example.com/crew/api/v1",
+			Controller: true,
+		}}))
+
+		dockerfile, err := afero.ReadFile(fs, filepath.Join(dir, "Dockerfile"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(dockerfile)).To(Equal("FROM golang:1.15 as builder\nUSER 65532:65532\n"))
+		controller, err := afero.ReadFile(fs, filepath.Join(dir, "controllers", "captain_controller.go"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(controller)).To(ContainSubstring("resources=captains/finalizers,verbs=update"))
+		Expect(afero.Exists(fs, filepath.Join(dir, ".dockerignore"))).To(BeTrue())
+
+		Expect(stdout.String()).To(ContainSubstring("The following steps must be performed manually:\n"))
+		Expect(stdout.String()).To(ContainSubstring("  - Makefile was not found"))
+		Expect(stdout.String()).To(ContainSubstring("  - main.go: restore the '// +kubebuilder:scaffold:scheme' marker\n"))
+	})
+
+	It("should not write to disk in dry-run mode", func() {
+		Expect(run("--dry-run")).To(Succeed())
+
+		cfg, err := internalconfig.ReadFromFs(afero.NewBasePathFs(fs, dir), internalconfig.DefaultPath)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cfg.Version).To(Equal(config.Version2))
+		Expect(stdout.String()).To(ContainSubstring("  overwritten    Dockerfile\n"))
+	})
+
+	It("should refuse to migrate a project twice", func() {
+		Expect(run()).To(Succeed())
+		err := run()
+		Expect(ExitCode(err)).To(Equal(2))
+		Expect(stderr.String()).To(ContainSubstring(`only projects with version "2" can be migrated`))
+	})
+})
@@ -0,0 +1,115 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"sigs.k8s.io/kubebuilder/internal/config"
+	"sigs.k8s.io/kubebuilder/pkg/plugin"
+)
+
+// addPluginCommands adds the commands contributed by the resolved plugins, and the ones
+// other plugins contribute to projects of the current project version, to the root command
+// or to the alpha command group. Commands must not collide with existing ones.
+func (c cli) addPluginCommands(rootCmd, alphaCmd *cobra.Command) error {
+	resolved := make(map[string]bool, len(c.resolvedPlugins))
+	for _, p := range c.resolvedPlugins {
+		resolved[plugin.KeyFor(p)] = true
+	}
+	plugins := append([]plugin.Base{}, c.resolvedPlugins...)
+	for _, p := range c.getAllPlugins() {
+		if !resolved[plugin.KeyFor(p)] {
+			plugins = append(plugins, p)
+		}
+	}
+
+	for _, p := range plugins {
+		getter, isGetter := p.(plugin.ExtraCommandsGetter)
+		if !isGetter {
+			continue
+		}
+
+		for _, extra := range getter.GetExtraCommands() {
+			if !resolved[plugin.KeyFor(p)] && !hasProjectVersion(extra, c.projectVersion) {
+				continue
+			}
+
+			parent := rootCmd
+			if extra.Alpha {
+				parent = alphaCmd
+			}
+			for _, subCmd := range parent.Commands() {
+				if subCmd.Name() == extra.Name {
+					return fmt.Errorf("plugin %q command %q already exists", plugin.KeyFor(p), subCmd.CommandPath())
+				}
+			}
+			parent.AddCommand(c.newPluginCmd(p, extra))
+		}
+	}
+	return nil
+}
+
+// hasProjectVersion returns true if extra is added to projects with the given version besides its plugin layout.
+func hasProjectVersion(extra plugin.ExtraCommand, projectVersion string) bool {
+	for _, version := range extra.ProjectVersions {
+		if version == projectVersion {
+			return true
+		}
+	}
+	return false
+}
+
+func (c cli) newPluginCmd(p plugin.Base, extra plugin.ExtraCommand) *cobra.Command {
+	ctx := c.newContext()
+	ctx.Description = fmt.Sprintf("%s.\n", extra.Short)
+	cmd := &cobra.Command{
+		Use:   extra.Name,
+		Short: extra.Short,
+		Long:  ctx.Description,
+		RunE: errCmdFunc(
+			fmt.Errorf("%s subcommand requires an existing project", extra.Name),
+		),
+	}
+
+	// Bind the plugin to the command.
+	c.bindPluginCmd(p, extra, ctx, cmd)
+	return cmd
+}
+
+func (c cli) bindPluginCmd(p plugin.Base, extra plugin.ExtraCommand, ctx plugin.Context, cmd *cobra.Command) {
+	cfg, err := config.LoadInitializedFs(c.fs)
+	if err != nil {
+		cmdErr(cmd, err)
+		return
+	}
+
+	sub := extra.Subcommand
+	sub.InjectConfig(&cfg.Config)
+	sub.BindFlags(cmd.Flags())
+	if err = c.applyFlagDefaults(cmd.Flags()); err != nil {
+		cmdErr(cmd, err)
+		return
+	}
+	sub.UpdateContext(&ctx)
+	cmd.Long = ctx.Description
+	cmd.Example = ctx.Examples
+	cmd.RunE = c.runECmdFunc(cfg, []string{plugin.KeyFor(p)}, sub, ctx,
+		fmt.Sprintf("failed to run %s", cmd.CommandPath()))
+}
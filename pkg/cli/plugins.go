@@ -100,6 +100,30 @@ func resolvePluginsByKey(versionedPlugins []plugin.Base, pluginKey string) (reso
 	return resolved, nil
 }
 
+// resolvePluginChain resolves each key of an ordered plugin chain to a single plugin,
+// looking the key up in defaultPlugins first and in allPlugins otherwise, as described
+// in resolvePluginsByKey. Resolving the same plugin twice results in an error.
+func resolvePluginChain(defaultPlugins, allPlugins []plugin.Base, keys []string) ([]plugin.Base, error) {
+	chain := make([]plugin.Base, 0, len(keys))
+	seen := make(map[string]struct{}, len(keys))
+	for _, key := range keys {
+		resolved, err := resolvePluginsByKey(defaultPlugins, key)
+		if err != nil {
+			if resolved, err = resolvePluginsByKey(allPlugins, key); err != nil {
+				return nil, err
+			}
+		}
+
+		pluginKey := plugin.KeyFor(resolved[0])
+		if _, isSeen := seen[pluginKey]; isSeen {
+			return nil, fmt.Errorf("plugin %q appears more than once in the plugin chain", pluginKey)
+		}
+		seen[pluginKey] = struct{}{}
+		chain = append(chain, resolved[0])
+	}
+	return chain, nil
+}
+
 // findPluginsMatchingName returns a set of plugins with Name() exactly
 // matching name.
 func findPluginsMatchingName(plugins []plugin.Base, name string) (equal []plugin.Base) {
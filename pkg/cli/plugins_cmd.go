@@ -0,0 +1,227 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"sigs.k8s.io/kubebuilder/pkg/plugin"
+)
+
+// pluginInfo describes a plugin registered in the cli.
+type pluginInfo struct {
+	// Key is the fully qualified key of the plugin.
+	Key string `json:"key"`
+	// Stage is the stability of the plugin version, if not stable.
+	Stage string `json:"stage,omitempty"`
+	// DeprecationWarning is set if the plugin is deprecated.
+	DeprecationWarning string `json:"deprecationWarning,omitempty"`
+	// ProjectVersions are the project versions supported by the plugin.
+	ProjectVersions []string `json:"projectVersions"`
+	// Subcommands are the commands implemented by the plugin.
+	Subcommands []string `json:"subcommands"`
+	// DefaultFor are the project versions the plugin is the default plugin for.
+	DefaultFor []string `json:"defaultFor,omitempty"`
+	// Path is the executable of an external plugin.
+	Path string `json:"path,omitempty"`
+}
+
+func (c cli) newPluginsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "plugins",
+		Short: "Inspect the plugins available to this binary",
+		Long: `Inspect the plugins available to this binary.
+
+Set --output to json or yaml for a machine-readable output.
+`,
+	}
+	cmd.AddCommand(c.newPluginsListCmd(), c.newPluginsDescribeCmd())
+	return cmd
+}
+
+func (c cli) newPluginsListCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List the available plugins",
+		Example: fmt.Sprintf(`  # List the plugins as a table
+  %[1]s plugins list
+
+  # List the plugins as JSON
+  %[1]s plugins list --output json`, c.commandName),
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			infos := c.getPluginInfos()
+			if c.output != "" {
+				return printOutput(cmd.OutOrStdout(), c.output, infos)
+			}
+			return printPluginTable(cmd.OutOrStdout(), infos)
+		},
+	}
+}
+
+func (c cli) newPluginsDescribeCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "describe <plugin key>",
+		Short: "Describe a plugin",
+		Example: fmt.Sprintf(`  # Describe the go plugin for project version 3-alpha
+  %[1]s plugins describe go/v3-alpha`, c.commandName),
+		Args: func(cmd *cobra.Command, args []string) error {
+			return newError(UsageError, cobra.ExactArgs(1)(cmd, args))
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			resolved, err := resolvePluginsByKey(c.getAllPlugins(), args[0])
+			if err != nil {
+				return newError(UsageError, err)
+			}
+			info := c.newPluginInfo(resolved[0])
+			if c.output != "" {
+				return printOutput(cmd.OutOrStdout(), c.output, info)
+			}
+			return printPluginDescription(cmd.OutOrStdout(), info)
+		},
+	}
+}
+
+// getAllPlugins returns every plugin injected by options, once, sorted by key.
+func (c cli) getAllPlugins() (plugins []plugin.Base) {
+	seen := make(map[string]struct{})
+	for _, versionedPlugins := range c.pluginsFromOptions {
+		for _, p := range versionedPlugins {
+			key := plugin.KeyFor(p)
+			if _, isSeen := seen[key]; !isSeen {
+				seen[key] = struct{}{}
+				plugins = append(plugins, p)
+			}
+		}
+	}
+	sort.Slice(plugins, func(i, j int) bool { return plugin.KeyFor(plugins[i]) < plugin.KeyFor(plugins[j]) })
+	return plugins
+}
+
+// getPluginInfos returns the description of every plugin, sorted by key.
+func (c cli) getPluginInfos() []pluginInfo {
+	plugins := c.getAllPlugins()
+	infos := make([]pluginInfo, 0, len(plugins))
+	for _, p := range plugins {
+		infos = append(infos, c.newPluginInfo(p))
+	}
+	return infos
+}
+
+func (c cli) newPluginInfo(p plugin.Base) pluginInfo {
+	info := pluginInfo{
+		Key:             plugin.KeyFor(p),
+		Stage:           p.Version().Stage,
+		ProjectVersions: p.SupportedProjectVersions(),
+		Subcommands:     getPluginSubcommands(p),
+	}
+	if d, isDeprecated := p.(plugin.Deprecated); isDeprecated {
+		info.DeprecationWarning = d.DeprecationWarning()
+	}
+	for version, defaultPlugin := range c.defaultPluginsFromOptions {
+		if plugin.KeyFor(defaultPlugin) == info.Key {
+			info.DefaultFor = append(info.DefaultFor, version)
+		}
+	}
+	sort.Strings(info.DefaultFor)
+	if e, isExternal := p.(interface{ Path() string }); isExternal {
+		info.Path = e.Path()
+	}
+	return info
+}
+
+// getPluginSubcommands returns the commands implemented by p. Getters return
+// nil if the plugin does not implement the subcommand.
+func getPluginSubcommands(p plugin.Base) []string {
+	subcommands := []string{}
+	if getter, isGetter := p.(plugin.InitPluginGetter); isGetter && getter.GetInitPlugin() != nil {
+		subcommands = append(subcommands, "init")
+	}
+	if getter, isGetter := p.(plugin.CreateAPIPluginGetter); isGetter && getter.GetCreateAPIPlugin() != nil {
+		subcommands = append(subcommands, "create api")
+	}
+	if getter, isGetter := p.(plugin.CreateWebhookPluginGetter); isGetter && getter.GetCreateWebhookPlugin() != nil {
+		subcommands = append(subcommands, "create webhook")
+	}
+	if getter, isGetter := p.(plugin.DeleteAPIPluginGetter); isGetter && getter.GetDeleteAPIPlugin() != nil {
+		subcommands = append(subcommands, "delete api")
+	}
+	if getter, isGetter := p.(plugin.DeleteWebhookPluginGetter); isGetter && getter.GetDeleteWebhookPlugin() != nil {
+		subcommands = append(subcommands, "delete webhook")
+	}
+	if getter, isGetter := p.(plugin.EditPluginGetter); isGetter && getter.GetEditPlugin() != nil {
+		subcommands = append(subcommands, "edit")
+	}
+	if getter, isGetter := p.(plugin.ExtraCommandsGetter); isGetter {
+		for _, extra := range getter.GetExtraCommands() {
+			if extra.Alpha {
+				subcommands = append(subcommands, "alpha "+extra.Name)
+			} else {
+				subcommands = append(subcommands, extra.Name)
+			}
+		}
+	}
+	return subcommands
+}
+
+// printPluginTable writes a table with a row per plugin to w.
+func printPluginTable(w io.Writer, infos []pluginInfo) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "KEY\tSTAGE\tPROJECT VERSIONS\tSUBCOMMANDS\tDEFAULT FOR\tDEPRECATED")
+	for _, info := range infos {
+		deprecated := ""
+		if info.DeprecationWarning != "" {
+			deprecated = "yes"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n",
+			info.Key,
+			info.Stage,
+			strings.Join(info.ProjectVersions, ","),
+			strings.Join(info.Subcommands, ","),
+			strings.Join(info.DefaultFor, ","),
+			deprecated,
+		)
+	}
+	return tw.Flush()
+}
+
+// printPluginDescription writes the fields of info to w, one per line.
+func printPluginDescription(w io.Writer, info pluginInfo) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintf(tw, "Key:\t%s\n", info.Key)
+	if info.Stage != "" {
+		fmt.Fprintf(tw, "Stage:\t%s\n", info.Stage)
+	}
+	fmt.Fprintf(tw, "Project versions:\t%s\n", strings.Join(info.ProjectVersions, ", "))
+	fmt.Fprintf(tw, "Subcommands:\t%s\n", strings.Join(info.Subcommands, ", "))
+	if len(info.DefaultFor) != 0 {
+		fmt.Fprintf(tw, "Default for:\t%s\n", strings.Join(info.DefaultFor, ", "))
+	}
+	if info.Path != "" {
+		fmt.Fprintf(tw, "Path:\t%s\n", info.Path)
+	}
+	if info.DeprecationWarning != "" {
+		fmt.Fprintf(tw, "Deprecated:\t%s\n", info.DeprecationWarning)
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,95 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"bytes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"sigs.k8s.io/kubebuilder/pkg/model/config"
+	"sigs.k8s.io/kubebuilder/pkg/plugin"
+	pluginv3 "sigs.k8s.io/kubebuilder/pkg/plugin/v3"
+)
+
+type mockDeprecatedPlugin struct {
+	mockPlugin
+}
+
+// DeprecationWarning will return the deprecation warning of the plugin
+func (mockDeprecatedPlugin) DeprecationWarning() string { return "use go.example.com/v2 instead" }
+
+var _ = Describe("plugins", func() {
+
+	var (
+		c CLI
+	)
+
+	BeforeEach(func() {
+		pluginAV1 := makeBasePlugin("go.example.com", "v1-alpha", config.Version2)
+		pluginAV2 := makeAllPlugin("go.example.com", "v2", config.Version2, config.Version3Alpha)
+		pluginB := makeExtraCommandsPlugin("go.test.com", "v1",
+			plugin.ExtraCommand{Name: "bundle", Alpha: true, Subcommand: mockPlugin{}},
+		)
+
+		var err error
+		c, err = New(
+			WithDefaultPlugins(pluginAV2),
+			WithPlugins(mockDeprecatedPlugin{pluginAV1.(mockPlugin)}, pluginAV2, pluginB),
+		)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("should describe every plugin", func() {
+		Expect(c.(*cli).getPluginInfos()).To(Equal([]pluginInfo{
+			{
+				Key:                "go.example.com/v1-alpha",
+				Stage:              plugin.AlphaStage,
+				DeprecationWarning: "use go.example.com/v2 instead",
+				ProjectVersions:    []string{config.Version2},
+				Subcommands:        []string{},
+			},
+			{
+				Key:             "go.example.com/v2",
+				ProjectVersions: []string{config.Version2, config.Version3Alpha},
+				Subcommands:     []string{"init", "create api", "create webhook", "edit"},
+				DefaultFor:      []string{config.Version2, config.Version3Alpha},
+			},
+			{
+				Key:             "go.test.com/v1",
+				ProjectVersions: []string{config.Version3Alpha},
+				Subcommands:     []string{"alpha bundle"},
+			},
+		}))
+	})
+
+	It("should describe every subcommand of a plugin", func() {
+		Expect(getPluginSubcommands(pluginv3.Plugin{})).To(Equal([]string{
+			"init", "create api", "create webhook", "delete api", "delete webhook", "edit",
+			"alpha migrate", "alpha regenerate", "alpha export-templates",
+		}))
+	})
+
+	It("should print the plugins as a table", func() {
+		var out bytes.Buffer
+		Expect(printPluginTable(&out, c.(*cli).getPluginInfos())).To(Succeed())
+		Expect(out.String()).To(HavePrefix("KEY "))
+		Expect(out.String()).To(ContainSubstring("go.example.com/v1-alpha  alpha"))
+		Expect(out.String()).To(ContainSubstring("init,create api,create webhook,edit  2,3-alpha"))
+	})
+})
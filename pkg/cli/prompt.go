@@ -0,0 +1,141 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"golang.org/x/term"
+
+	"sigs.k8s.io/kubebuilder/pkg/plugin"
+)
+
+const (
+	noPromptFlag = "no-prompt"
+	yesFlag      = "yes"
+)
+
+var _ plugin.Prompter = &prompter{}
+
+// prompter implements plugin.Prompter reading the answers line by line.
+type prompter struct {
+	in  *bufio.Reader
+	out io.Writer
+	// terminal is true if in is an interactive terminal, otherwise questions fail without prompting.
+	terminal bool
+	// assumeYes answers yes to every question without prompting.
+	assumeYes bool
+	// noPrompt fails instead of prompting.
+	noPrompt bool
+}
+
+// newPrompter returns the prompter used by the cli, reading answers from its stdin
+// and writing the questions to out.
+func (c cli) newPrompter(out io.Writer) *prompter {
+	return &prompter{
+		in:        bufio.NewReader(c.stdin),
+		out:       out,
+		terminal:  isTerminal(c.stdin),
+		assumeYes: c.assumeYes,
+		noPrompt:  c.noPrompt,
+	}
+}
+
+// isTerminal returns true if r is an interactive terminal. Other character devices, like /dev/null, are not.
+func isTerminal(r io.Reader) bool {
+	f, isFile := r.(*os.File)
+	return isFile && term.IsTerminal(int(f.Fd()))
+}
+
+// Confirm implements plugin.Prompter
+func (p *prompter) Confirm(questions ...plugin.Question) error {
+	if p.assumeYes {
+		for _, q := range questions {
+			*q.Answer = true
+		}
+		return nil
+	}
+	if len(questions) == 0 {
+		return nil
+	}
+	if p.noPrompt {
+		return fmt.Errorf("prompts are disabled by --%s, set the missing flags: %s",
+			noPromptFlag, missingFlags(questions))
+	}
+	// Reading from a non-interactive stdin that is kept open would block forever
+	if !p.terminal {
+		return fmt.Errorf("stdin is not a terminal, set the missing flags: %s", missingFlags(questions))
+	}
+
+	for i, q := range questions {
+		answered, err := p.ask(q)
+		if err != nil {
+			return err
+		}
+		if !answered {
+			return fmt.Errorf("no answer was provided, set the missing flags: %s", missingFlags(questions[i:]))
+		}
+	}
+	return nil
+}
+
+// ask prompts q until a valid answer is read, returning false if the input was closed before.
+// An empty answer keeps the default one.
+func (p *prompter) ask(q plugin.Question) (bool, error) {
+	options := "[y/N]"
+	if *q.Answer {
+		options = "[Y/n]"
+	}
+
+	for {
+		fmt.Fprintf(p.out, "%s %s\n", q.Message, options)
+		text, err := p.in.ReadString('\n')
+		if err != nil && err != io.EOF {
+			return false, fmt.Errorf("error when reading input: %v", err)
+		}
+		text = strings.ToLower(strings.TrimSpace(text))
+		if err == io.EOF && text == "" {
+			return false, nil
+		}
+
+		switch text {
+		case "":
+			return true, nil
+		case "y", "yes":
+			*q.Answer = true
+			return true, nil
+		case "n", "no":
+			*q.Answer = false
+			return true, nil
+		default:
+			fmt.Fprintf(p.out, "invalid input %q, should be [y/n]\n", text)
+		}
+	}
+}
+
+// missingFlags returns the flags that answer questions formatted for error messages.
+func missingFlags(questions []plugin.Question) string {
+	flags := make([]string, 0, len(questions))
+	for _, q := range questions {
+		flags = append(flags, "--"+q.Flag)
+	}
+	return strings.Join(flags, ", ")
+}
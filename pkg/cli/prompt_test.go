@@ -0,0 +1,102 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"bufio"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"sigs.k8s.io/kubebuilder/pkg/plugin"
+)
+
+var _ = Describe("prompter", func() {
+	var (
+		resource, controller bool
+		questions            []plugin.Question
+	)
+
+	newTestPrompter := func(input string) *prompter {
+		return &prompter{in: bufio.NewReader(strings.NewReader(input)), out: ioutil.Discard, terminal: true}
+	}
+
+	BeforeEach(func() {
+		resource, controller = true, true
+		questions = []plugin.Question{
+			{Message: "Create Resource", Flag: "resource", Answer: &resource},
+			{Message: "Create Controller", Flag: "controller", Answer: &controller},
+		}
+	})
+
+	It("should read the answers from the input", func() {
+		Expect(newTestPrompter("n\nyes\n").Confirm(questions...)).To(Succeed())
+		Expect(resource).To(BeFalse())
+		Expect(controller).To(BeTrue())
+	})
+
+	It("should keep the default answer for empty lines and retry invalid ones", func() {
+		Expect(newTestPrompter("\nmaybe\nno").Confirm(questions...)).To(Succeed())
+		Expect(resource).To(BeTrue())
+		Expect(controller).To(BeFalse())
+	})
+
+	It("should name the unanswered flags when the input is closed", func() {
+		err := newTestPrompter("y\n").Confirm(questions...)
+		Expect(err).To(MatchError("no answer was provided, set the missing flags: --controller"))
+	})
+
+	It("should fail naming every missing flag without reading when the input is not a terminal", func() {
+		p := newTestPrompter("y\ny\n")
+		p.terminal = false
+		Expect(p.Confirm(questions...)).To(MatchError(
+			"stdin is not a terminal, set the missing flags: --resource, --controller"))
+		Expect(p.in.Buffered()).To(BeZero())
+	})
+
+	It("should not prompt when stdin is /dev/null", func() {
+		devNull, err := os.Open(os.DevNull)
+		Expect(err).NotTo(HaveOccurred())
+		defer devNull.Close()
+
+		var out strings.Builder
+		p := cli{stdin: devNull}.newPrompter(&out)
+		Expect(p.Confirm(questions...)).To(MatchError(
+			"stdin is not a terminal, set the missing flags: --resource, --controller"))
+		Expect(out.String()).To(BeEmpty())
+	})
+
+	It("should answer yes without reading the input when assuming yes", func() {
+		resource, controller = false, false
+		p := newTestPrompter("")
+		p.assumeYes = true
+		Expect(p.Confirm(questions...)).To(Succeed())
+		Expect(resource).To(BeTrue())
+		Expect(controller).To(BeTrue())
+	})
+
+	It("should fail naming every missing flag when prompts are disabled", func() {
+		p := newTestPrompter("y\ny\n")
+		p.noPrompt = true
+		Expect(p.Confirm(questions...)).To(MatchError(
+			"prompts are disabled by --no-prompt, set the missing flags: --resource, --controller"))
+		Expect(p.Confirm()).To(Succeed())
+	})
+})
@@ -0,0 +1,166 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/spf13/afero"
+)
+
+var _ = Describe("alpha regenerate", func() {
+	var (
+		dir = filepath.Join(string(filepath.Separator), "projects", "crew")
+
+		fs             afero.Fs
+		stdout, stderr bytes.Buffer
+	)
+
+	BeforeEach(func() {
+		fs = afero.NewMemMapFs()
+		stdout.Reset()
+		stderr.Reset()
+
+		files := map[string]string{
+			"PROJECT": `domain: example.com
+layout: go.kubebuilder.io/v3-alpha
+projectName: crew
+repo: example.com/crew
+resources:
+- controller: true
+  domain: crew.example.com
+  group: crew
+  kind: Captain
+  path: example.com/crew/api/v1
+  plural: captains
+  scope: Namespaced
+  version: v1
+version: 3-alpha
+`,
+			filepath.Join("hack", "boilerplate.go.txt"): "/*\nCopyright 2020 The Crew Authors.\n*/",
+		}
+		for path, content := range files {
+			Expect(afero.WriteFile(fs, filepath.Join(dir, path), []byte(content), 0644)).To(Succeed())
+		}
+	})
+
+	run := func(args ...string) error {
+		return runProject(fs, dir, &stdout, &stderr, args...)
+	}
+
+	read := func(path ...string) string {
+		b, err := afero.ReadFile(fs, filepath.Join(append([]string{dir}, path...)...))
+		Expect(err).NotTo(HaveOccurred())
+		return string(b)
+	}
+
+	write := func(content string, path ...string) {
+		Expect(afero.WriteFile(fs, filepath.Join(append([]string{dir}, path...)...), []byte(content), 0644)).
+			To(Succeed())
+	}
+
+	It("should scaffold the missing files and store the baseline", func() {
+		Expect(run("alpha", "regenerate")).To(Succeed())
+
+		Expect(read("main.go")).To(ContainSubstring("controllers.CaptainReconciler"))
+		Expect(read("main.go")).To(ContainSubstring("Copyright 2020 The Crew Authors."))
+		Expect(read(".kubebuilder", "baseline", "main.go")).To(Equal(read("main.go")))
+		Expect(read(".kubebuilder", "baseline", "config", "crd", "kustomization.yaml")).
+			To(ContainSubstring("- bases/crew.example.com_captains.yaml"))
+	})
+
+	It("should merge the changes of the scaffold into the changes of the project", func() {
+		Expect(run("alpha", "regenerate")).To(Succeed())
+
+		By("simulating a previous scaffold and changes in the project")
+		dockerfile := read("Dockerfile")
+		old := strings.Replace(dockerfile, "FROM golang:1.15", "FROM golang:1.13", 1)
+		write(old, ".kubebuilder", "baseline", "Dockerfile")
+		write(strings.Replace(old, "COPY api/ api/\n", "COPY api/ api/\nCOPY pkg/ pkg/\n", 1), "Dockerfile")
+		makefile := read("Makefile")
+		write(strings.Replace(makefile, "IMG ?= controller:latest", "IMG ?= old:latest", 1),
+			".kubebuilder", "baseline", "Makefile")
+		write(strings.Replace(makefile, "IMG ?= controller:latest", "IMG ?= mine:latest", 1), "Makefile")
+
+		Expect(run("alpha", "regenerate")).To(Succeed())
+		Expect(read("Dockerfile")).To(Equal(
+			strings.Replace(dockerfile, "COPY api/ api/\n", "COPY api/ api/\nCOPY pkg/ pkg/\n", 1)))
+		Expect(read(".kubebuilder", "baseline", "Dockerfile")).To(Equal(dockerfile))
+		Expect(read("Makefile")).To(ContainSubstring(
+			"<<<<<<< current\nIMG ?= mine:latest\n=======\nIMG ?= controller:latest\n>>>>>>> scaffolded\n"))
+		Expect(stdout.String()).To(ContainSubstring("Makefile: resolve the 1 conflict(s)"))
+	})
+
+	It("should merge from the baseline recorded by create api and create webhook", func() {
+		Expect(run("alpha", "regenerate")).To(Succeed())
+		write(strings.Replace(read("main.go"), `"metrics-addr", ":8080"`, `"metrics-addr", ":9090"`, 1), "main.go")
+
+		Expect(run("create", "api", "--group", "crew", "--version", "v1", "--kind", "FirstMate",
+			"--resource", "--controller", "--make=false")).To(Succeed())
+		Expect(run("create", "webhook", "--group", "crew", "--version", "v1", "--kind", "Captain",
+			"--defaulting")).To(Succeed())
+		Expect(read(".kubebuilder", "baseline", "main.go")).To(ContainSubstring("controllers.FirstMateReconciler"))
+		Expect(read(".kubebuilder", "baseline", "main.go")).To(ContainSubstring("SetupWebhookWithManager"))
+		Expect(read(".kubebuilder", "baseline", "main.go")).To(ContainSubstring(`"metrics-addr", ":8080"`))
+
+		stdout.Reset()
+		Expect(run("alpha", "regenerate")).To(Succeed())
+		Expect(read("main.go")).To(ContainSubstring(`"metrics-addr", ":9090"`))
+		Expect(read("main.go")).NotTo(ContainSubstring("<<<<<<<"))
+		Expect(stdout.String()).NotTo(ContainSubstring("conflict"))
+	})
+
+	It("should only update the baselines of the files changed by create api and delete api", func() {
+		Expect(run("alpha", "regenerate")).To(Succeed())
+
+		By("simulating a baseline scaffolded by a previous version")
+		makefile := strings.Replace(read("Makefile"), "IMG ?= controller:latest", "IMG ?= old:latest", 1)
+		write(makefile, ".kubebuilder", "baseline", "Makefile")
+		main := strings.Replace(read("main.go"), `"metrics-addr", ":8080"`, `"metrics-addr", ":8081"`, 1)
+		write(main, ".kubebuilder", "baseline", "main.go")
+
+		Expect(run("create", "api", "--group", "crew", "--version", "v1", "--kind", "FirstMate",
+			"--resource", "--controller", "--make=false")).To(Succeed())
+		Expect(read(".kubebuilder", "baseline", "Makefile")).To(Equal(makefile))
+		Expect(read(".kubebuilder", "baseline", "main.go")).To(ContainSubstring(`"metrics-addr", ":8081"`))
+		Expect(read(".kubebuilder", "baseline", "main.go")).To(ContainSubstring("controllers.FirstMateReconciler"))
+		Expect(read(".kubebuilder", "baseline", "api", "v1", "firstmate_types.go")).
+			To(Equal(read("api", "v1", "firstmate_types.go")))
+		Expect(stdout.String()).NotTo(ContainSubstring(filepath.Join(".kubebuilder", "baseline", "Makefile")))
+
+		Expect(run("delete", "api", "--group", "crew", "--version", "v1", "--kind", "FirstMate", "--yes")).
+			To(Succeed())
+		Expect(read(".kubebuilder", "baseline", "main.go")).To(Equal(main))
+		exists, err := afero.Exists(fs, filepath.Join(dir, ".kubebuilder", "baseline", "api", "v1", "firstmate_types.go"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(exists).To(BeFalse())
+	})
+
+	It("should not write anything in dry-run mode", func() {
+		Expect(run("alpha", "regenerate", "--dry-run")).To(Succeed())
+
+		exists, err := afero.Exists(fs, filepath.Join(dir, "main.go"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(exists).To(BeFalse())
+		Expect(stdout.String()).To(ContainSubstring("  created        main.go\n"))
+	})
+})
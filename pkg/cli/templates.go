@@ -0,0 +1,43 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"path/filepath"
+
+	"github.com/spf13/afero"
+)
+
+const (
+	templateDirFlag = "template-dir"
+
+	// defaultTemplateDir is the directory of the project the template overrides are read from by default.
+	defaultTemplateDir = ".kubebuilder/templates"
+)
+
+// newTemplatesFs returns the file system of the template directory, which is set by --template-dir or defaults
+// to the one of the project. root is the file system the working directory and absolute paths refer to.
+func (c cli) newTemplatesFs(root afero.Fs) afero.Fs {
+	dir := c.templateDir
+	if dir == "" {
+		dir = filepath.FromSlash(defaultTemplateDir)
+	}
+	if !filepath.IsAbs(dir) {
+		dir = filepath.Join(c.workingDir, dir)
+	}
+	return afero.NewBasePathFs(root, dir)
+}
@@ -0,0 +1,118 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"bytes"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/spf13/afero"
+)
+
+var _ = Describe("template overrides", func() {
+	var (
+		dir = filepath.Join(string(filepath.Separator), "projects", "crew")
+
+		fs             afero.Fs
+		stdout, stderr bytes.Buffer
+	)
+
+	BeforeEach(func() {
+		fs = afero.NewMemMapFs()
+		stdout.Reset()
+		stderr.Reset()
+
+		files := map[string]string{
+			"PROJECT": `domain: example.com
+layout: go.kubebuilder.io/v3-alpha
+projectName: crew
+repo: example.com/crew
+version: 3-alpha
+`,
+			filepath.Join("hack", "boilerplate.go.txt"): "/*\nCopyright 2020 The Crew Authors.\n*/",
+		}
+		for path, content := range files {
+			Expect(afero.WriteFile(fs, filepath.Join(dir, path), []byte(content), 0644)).To(Succeed())
+		}
+	})
+
+	run := func(args ...string) error {
+		return runProject(fs, dir, &stdout, &stderr, args...)
+	}
+
+	read := func(path ...string) string {
+		b, err := afero.ReadFile(fs, filepath.Join(path...))
+		Expect(err).NotTo(HaveOccurred())
+		return string(b)
+	}
+
+	It("should export the default templates to the template directory of the project", func() {
+		Expect(run("alpha", "export-templates")).To(Succeed())
+
+		Expect(read(dir, ".kubebuilder", "templates", "templates.Dockerfile.tmpl")).
+			To(ContainSubstring("FROM golang:1.15 as builder"))
+		Expect(read(dir, ".kubebuilder", "templates", "controller.Controller.tmpl")).
+			To(ContainSubstring("{{ .Resource.Kind }}Reconciler"))
+
+		By("keeping the templates which were already exported")
+		path := filepath.Join(dir, ".kubebuilder", "templates", "templates.Dockerfile.tmpl")
+		Expect(afero.WriteFile(fs, path, []byte("FROM scratch\n"), 0644)).To(Succeed())
+		Expect(run("alpha", "export-templates")).To(Succeed())
+		Expect(read(path)).To(Equal("FROM scratch\n"))
+		Expect(run("alpha", "export-templates", "--force")).To(Succeed())
+		Expect(read(path)).To(ContainSubstring("FROM golang:1.15 as builder"))
+	})
+
+	It("should scaffold the same files with the exported templates as without them", func() {
+		Expect(afero.WriteFile(fs, filepath.Join(dir, "PROJECT"), []byte(`domain: example.com
+layout: go.kubebuilder.io/v3-alpha
+projectName: crew
+repo: example.com/crew
+resources:
+- domain: crew.example.com
+  group: crew
+  kind: Captain
+  version: v1
+  webhooks:
+    defaulting: true
+version: 3-alpha
+`), 0644)).To(Succeed())
+		Expect(run("alpha", "export-templates")).To(Succeed())
+
+		Expect(run("alpha", "regenerate")).To(Succeed())
+		webhook := read(dir, "api", "v1", "captain_webhook.go")
+		Expect(webhook).To(ContainSubstring("func (r *Captain) Default()"))
+		Expect(webhook).NotTo(ContainSubstring("ValidateCreate"))
+	})
+
+	It("should scaffold the files with the templates of --template-dir", func() {
+		templateDir := filepath.Join(string(filepath.Separator), "templates")
+		Expect(run("alpha", "export-templates", "--template-dir", templateDir)).To(Succeed())
+		Expect(afero.WriteFile(fs, filepath.Join(templateDir, "templates.Dockerfile.tmpl"),
+			[]byte("FROM scratch\n"), 0644)).To(Succeed())
+		Expect(afero.WriteFile(fs, filepath.Join(templateDir, "Makefile.tmpl"),
+			[]byte("IMG ?= {{ .Image }}\n"), 0644)).To(Succeed())
+
+		Expect(run("alpha", "regenerate", "--template-dir", templateDir)).To(Succeed())
+		Expect(read(dir, "Dockerfile")).To(Equal("FROM scratch\n"))
+		Expect(read(dir, "Makefile")).To(Equal("IMG ?= controller:latest\n"))
+		Expect(read(dir, "main.go")).To(ContainSubstring("Copyright 2020 The Crew Authors."))
+	})
+})
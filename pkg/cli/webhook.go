@@ -25,6 +25,13 @@ import (
 	"sigs.k8s.io/kubebuilder/pkg/plugin"
 )
 
+// Flags identifying the resource of a webhook, which are completed from the project config.
+const (
+	groupFlag   = "group"
+	versionFlag = "version"
+	kindFlag    = "kind"
+)
+
 func (c *cli) newCreateWebhookCmd() *cobra.Command {
 	ctx := c.newWebhookContext()
 	cmd := &cobra.Command{
@@ -32,6 +39,9 @@ func (c *cli) newCreateWebhookCmd() *cobra.Command {
 		Short:   "Scaffold a webhook for an API resource",
 		Long:    ctx.Description,
 		Example: ctx.Examples,
+		Args: func(cmd *cobra.Command, args []string) error {
+			return newError(UsageError, cobra.NoArgs(cmd, args))
+		},
 		RunE: errCmdFunc(
 			fmt.Errorf("webhook subcommand requires an existing project"),
 		),
@@ -43,11 +53,9 @@ func (c *cli) newCreateWebhookCmd() *cobra.Command {
 }
 
 func (c cli) newWebhookContext() plugin.Context {
-	ctx := plugin.Context{
-		CommandName: c.commandName,
-		Description: `Scaffold a webhook for an API resource.
-`,
-	}
+	ctx := c.newContext()
+	ctx.Description = `Scaffold a webhook for an API resource.
+`
 	if !c.configured {
 		ctx.Description = fmt.Sprintf("%s\n%s", ctx.Description, runInProjectRootMsg)
 	}
@@ -55,38 +63,45 @@ func (c cli) newWebhookContext() plugin.Context {
 }
 
 func (c cli) bindCreateWebhook(ctx plugin.Context, cmd *cobra.Command) {
-	var getter plugin.CreateWebhookPluginGetter
+	var (
+		keys        []string
+		subcommands []plugin.GenericSubcommand
+	)
 	for _, p := range c.resolvedPlugins {
-		tmpGetter, isGetter := p.(plugin.CreateWebhookPluginGetter)
-		if isGetter {
-			if getter != nil {
-				err := fmt.Errorf("duplicate webhook creation plugins for project version %q (%s, %s), "+
-					"use a more specific plugin key", c.projectVersion, plugin.KeyFor(getter), plugin.KeyFor(p))
-				cmdErr(cmd, err)
-				return
+		// Getters return nil if the plugin does not implement the subcommand.
+		if getter, isGetter := p.(plugin.CreateWebhookPluginGetter); isGetter {
+			if sub := getter.GetCreateWebhookPlugin(); sub != nil {
+				keys = append(keys, plugin.KeyFor(p))
+				subcommands = append(subcommands, sub)
 			}
-			getter = tmpGetter
 		}
 	}
 
-	cfg, err := config.LoadInitialized()
+	cfg, err := config.LoadInitializedFs(c.fs)
 	if err != nil {
 		cmdErr(cmd, err)
 		return
 	}
 
-	if getter == nil {
+	if len(subcommands) == 0 {
 		err := fmt.Errorf("layout plugin %q does not support a webhook creation plugin", cfg.Layout)
 		cmdErr(cmd, err)
 		return
 	}
 
-	createWebhook := getter.GetCreateWebhookPlugin()
+	createWebhook := newSubcommandChain(keys, subcommands)
 	createWebhook.InjectConfig(&cfg.Config)
 	createWebhook.BindFlags(cmd.Flags())
+	if err = c.applyFlagDefaults(cmd.Flags()); err != nil {
+		cmdErr(cmd, err)
+		return
+	}
+	for _, flag := range []string{groupFlag, versionFlag, kindFlag} {
+		c.registerFlagCompletion(cmd.Flags(), flag, c.completeResources(flag))
+	}
 	createWebhook.UpdateContext(&ctx)
 	cmd.Long = ctx.Description
 	cmd.Example = ctx.Examples
-	cmd.RunE = runECmdFunc(cfg, createWebhook,
+	cmd.RunE = c.runECmdFunc(cfg, keys, createWebhook, ctx,
 		fmt.Sprintf("failed to create webhook with version %q", c.projectVersion))
 }
@@ -0,0 +1,84 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cli
+
+import (
+	"bytes"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/spf13/afero"
+)
+
+var _ = Describe("create webhook", func() {
+	var (
+		dir = filepath.Join(string(filepath.Separator), "projects", "crew")
+
+		fs             afero.Fs
+		stdout, stderr bytes.Buffer
+	)
+
+	BeforeEach(func() {
+		fs = afero.NewMemMapFs()
+		stdout.Reset()
+		stderr.Reset()
+
+		files := map[string]string{
+			"PROJECT": `domain: example.com
+layout: go.kubebuilder.io/v3-alpha
+projectName: crew
+repo: example.com/crew
+resources:
+- controller: true
+  domain: crew.example.com
+  group: crew
+  kind: Captain
+  path: example.com/crew/api/v1
+  plural: captains
+  scope: Namespaced
+  version: v1
+version: 3-alpha
+`,
+			filepath.Join("hack", "boilerplate.go.txt"): "/*\nCopyright 2020 The Crew Authors.\n*/",
+		}
+		for path, content := range files {
+			Expect(afero.WriteFile(fs, filepath.Join(dir, path), []byte(content), 0644)).To(Succeed())
+		}
+		Expect(runProject(fs, dir, &stdout, &stderr, "alpha", "regenerate")).To(Succeed())
+		stdout.Reset()
+	})
+
+	run := func(args ...string) error {
+		return runProject(fs, dir, &stdout, &stderr, append([]string{"create", "webhook", "--version", "v1"}, args...)...)
+	}
+
+	It("should report the implementation of the conversion interfaces as a manual step", func() {
+		Expect(run("--group", "crew", "--kind", "Captain", "--conversion", "--dry-run")).To(Succeed())
+		Expect(stdout.String()).To(ContainSubstring("The following steps must be performed manually:\n" +
+			"  - implement the conversion.Hub and conversion.Convertible interfaces for your CRD types"))
+
+		By("failing to create the webhook")
+		stdout.Reset()
+		webhook := filepath.Join(dir, "api", "v1", "captain_webhook.go")
+		Expect(afero.WriteFile(fs, webhook, []byte("package v1\n"), 0644)).To(Succeed())
+		Expect(run("--group", "crew", "--kind", "Captain", "--conversion")).NotTo(Succeed())
+		Expect(stdout.String()).NotTo(ContainSubstring("conversion.Hub"))
+		Expect(stderr.String()).NotTo(ContainSubstring("conversion.Hub"))
+	})
+})
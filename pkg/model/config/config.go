@@ -17,7 +17,9 @@ limitations under the License.
 package config
 
 import (
+	"encoding/json"
 	"fmt"
+	"reflect"
 	"strings"
 
 	"sigs.k8s.io/yaml"
@@ -45,20 +47,73 @@ type Config struct {
 	ProjectName string `json:"projectName,omitempty"`
 
 	// Resources tracks scaffolded resources in the project
-	// This info is tracked only in project with version 2
+	// This info is tracked only in project with version 2+, and their metadata in version 3+
 	Resources []GVK `json:"resources,omitempty"`
 
 	// Multigroup tracks if the project has more than one group
 	MultiGroup bool `json:"multigroup,omitempty"`
 
-	// Layout contains a key specifying which plugin created a project.
-	Layout string `json:"layout,omitempty"`
+	// Layout contains the ordered keys of the plugins that created a project.
+	Layout Layout `json:"layout,omitempty"`
+
+	// Hooks contains the commands run before and after kubebuilder commands.
+	Hooks Hooks `json:"hooks,omitempty"`
 
 	// Plugins holds plugin-specific configs mapped by plugin key. These configs should be
 	// encoded/decoded using EncodePluginConfig/DecodePluginConfig, respectively.
 	Plugins PluginConfigs `json:"plugins,omitempty"`
 }
 
+// Layout is the ordered list of plugin keys that created a project. Each plugin in the list
+// runs, in order, for every command, so later plugins build on the files scaffolded by earlier ones.
+//
+// A layout with a single key is serialized as a string for backwards compatibility.
+type Layout []string
+
+// String returns the comma-separated plugin keys of the layout
+func (l Layout) String() string {
+	return strings.Join(l, ",")
+}
+
+// MarshalJSON implements json.Marshaler
+func (l Layout) MarshalJSON() ([]byte, error) {
+	if len(l) == 1 {
+		return json.Marshal(l[0])
+	}
+	return json.Marshal([]string(l))
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (l *Layout) UnmarshalJSON(b []byte) error {
+	var key string
+	if err := json.Unmarshal(b, &key); err == nil {
+		*l = nil
+		if key != "" {
+			*l = Layout{key}
+		}
+		return nil
+	}
+
+	var keys []string
+	if err := json.Unmarshal(b, &keys); err != nil {
+		return fmt.Errorf("layout must be a plugin key or a list of plugin keys: %v", err)
+	}
+	*l = keys
+	return nil
+}
+
+// Hooks maps kubebuilder command names, ex. "init" or "create api", to the commands run
+// before and after them.
+type Hooks map[string]CommandHooks
+
+// CommandHooks are the shell commands run before and after a kubebuilder command.
+type CommandHooks struct {
+	// Pre are run in order before the command scaffolds any file.
+	Pre []string `json:"pre,omitempty"`
+	// Post are run in order after the command has scaffolded its files and saved the config.
+	Post []string `json:"post,omitempty"`
+}
+
 // PluginConfigs holds a set of arbitrary plugin configuration objects mapped by plugin key.
 type PluginConfigs map[string]pluginConfig
 
@@ -93,9 +148,20 @@ func (c Config) HasResource(target GVK) bool {
 	return false
 }
 
+// GetResource returns the tracked resource with the group, version and kind of target, if any
+func (c Config) GetResource(target GVK) (GVK, bool) {
+	for _, r := range c.Resources {
+		if r.isEqualTo(target) {
+			return r, true
+		}
+	}
+	return GVK{}, false
+}
+
 // AddResource appends the provided resource to the tracked ones
 // It returns if the configuration was modified
 // NOTE: in v1 resources are not tracked, so we return false
+// NOTE: in v2 only the group, version and kind are tracked
 func (c *Config) AddResource(gvk GVK) bool {
 	// Short-circuit v1
 	if c.IsV1() {
@@ -108,10 +174,74 @@ func (c *Config) AddResource(gvk GVK) bool {
 	}
 
 	// Append the resource to the tracked ones, return true
-	c.Resources = append(c.Resources, gvk)
+	c.Resources = append(c.Resources, c.trackedFields(gvk))
+	return true
+}
+
+// UpdateResource merges the metadata of the provided resource into the tracked one, adding it
+// if it was not tracked yet. Metadata fields that are empty in gvk are not modified.
+// It returns if the configuration was modified
+func (c *Config) UpdateResource(gvk GVK) bool {
+	// Short-circuit v1
+	if c.IsV1() {
+		return false
+	}
+
+	for i, r := range c.Resources {
+		if r.isEqualTo(gvk) {
+			updated := c.trackedFields(r.merge(gvk))
+			if reflect.DeepEqual(updated, r) {
+				return false
+			}
+			c.Resources[i] = updated
+			return true
+		}
+	}
+
+	c.Resources = append(c.Resources, c.trackedFields(gvk))
 	return true
 }
 
+// RemoveResource removes the provided resource from the tracked ones
+// It returns if the configuration was modified
+// NOTE: in v1 resources are not tracked, so we return false
+func (c *Config) RemoveResource(gvk GVK) bool {
+	// Short-circuit v1
+	if c.IsV1() {
+		return false
+	}
+
+	for i, r := range c.Resources {
+		if r.isEqualTo(gvk) {
+			c.Resources = append(c.Resources[:i], c.Resources[i+1:]...)
+			return true
+		}
+	}
+
+	return false
+}
+
+// RemoveWebhooks stops tracking the webhooks of the provided resource
+// It returns if the configuration was modified
+func (c *Config) RemoveWebhooks(gvk GVK) bool {
+	for i, r := range c.Resources {
+		if r.isEqualTo(gvk) && r.Webhooks != nil {
+			c.Resources[i].Webhooks = nil
+			return true
+		}
+	}
+
+	return false
+}
+
+// trackedFields returns the fields of gvk tracked in the project version of c
+func (c Config) trackedFields(gvk GVK) GVK {
+	if !c.IsV3() {
+		return GVK{Group: gvk.Group, Version: gvk.Version, Kind: gvk.Kind}
+	}
+	return gvk
+}
+
 // HasGroup returns true if group is already tracked
 func (c Config) HasGroup(group string) bool {
 	// Return true if the target group is found in the tracked resources
@@ -125,11 +255,39 @@ func (c Config) HasGroup(group string) bool {
 	return false
 }
 
+// Resource scopes
+const (
+	NamespacedScope = "Namespaced"
+	ClusterScope    = "Cluster"
+)
+
 // GVK contains information about scaffolded resources
 type GVK struct {
 	Group   string `json:"group,omitempty"`
 	Version string `json:"version,omitempty"`
 	Kind    string `json:"kind,omitempty"`
+
+	// The following metadata is only tracked in project with version 3+
+
+	// Plural is the resource name, the plural form of the kind
+	Plural string `json:"plural,omitempty"`
+	// Scope is either NamespacedScope or ClusterScope
+	Scope string `json:"scope,omitempty"`
+	// Domain is the fully qualified group of the resource
+	Domain string `json:"domain,omitempty"`
+	// Path is the go package of the resource API types
+	Path string `json:"path,omitempty"`
+	// Controller is true if a controller was scaffolded for the resource
+	Controller bool `json:"controller,omitempty"`
+	// Webhooks tracks the webhooks scaffolded for the resource
+	Webhooks *Webhooks `json:"webhooks,omitempty"`
+}
+
+// Webhooks contains information about the webhooks scaffolded for a resource
+type Webhooks struct {
+	Defaulting bool `json:"defaulting,omitempty"`
+	Validation bool `json:"validation,omitempty"`
+	Conversion bool `json:"conversion,omitempty"`
 }
 
 // isEqualTo compares it with another resource
@@ -139,6 +297,34 @@ func (r GVK) isEqualTo(other GVK) bool {
 		r.Kind == other.Kind
 }
 
+// merge returns r with the non-empty metadata of other
+func (r GVK) merge(other GVK) GVK {
+	if other.Plural != "" {
+		r.Plural = other.Plural
+	}
+	if other.Scope != "" {
+		r.Scope = other.Scope
+	}
+	if other.Domain != "" {
+		r.Domain = other.Domain
+	}
+	if other.Path != "" {
+		r.Path = other.Path
+	}
+	r.Controller = r.Controller || other.Controller
+	if other.Webhooks != nil {
+		webhooks := Webhooks{}
+		if r.Webhooks != nil {
+			webhooks = *r.Webhooks
+		}
+		webhooks.Defaulting = webhooks.Defaulting || other.Webhooks.Defaulting
+		webhooks.Validation = webhooks.Validation || other.Webhooks.Validation
+		webhooks.Conversion = webhooks.Conversion || other.Webhooks.Conversion
+		r.Webhooks = &webhooks
+	}
+	return r
+}
+
 // Marshal returns the bytes of c.
 func (c Config) Marshal() ([]byte, error) {
 	// Ignore extra fields at first.
@@ -150,4 +150,108 @@ var _ = Describe("Config", func() {
 		Expect(config.DecodePluginConfig(key, &pluginConfig)).To(Succeed())
 		Expect(pluginConfig).To(Equal(expectedPluginConfig))
 	})
+
+	It("should marshal and unmarshal the layout", func() {
+		var config Config
+
+		By("Using a single plugin key")
+		config = Config{Version: Version3Alpha, Layout: Layout{"go.kubebuilder.io/v2"}}
+		b, err := config.Marshal()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(b)).To(Equal("layout: go.kubebuilder.io/v2\nversion: 3-alpha\n"))
+		config = Config{}
+		Expect(config.Unmarshal(b)).To(Succeed())
+		Expect(config.Layout).To(Equal(Layout{"go.kubebuilder.io/v2"}))
+
+		By("Using a chain of plugin keys")
+		config = Config{Version: Version3Alpha, Layout: Layout{"go.kubebuilder.io/v2", "addon.example.com/v1"}}
+		b, err = config.Marshal()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(b)).To(Equal("layout:\n- go.kubebuilder.io/v2\n- addon.example.com/v1\nversion: 3-alpha\n"))
+		config = Config{}
+		Expect(config.Unmarshal(b)).To(Succeed())
+		Expect(config.Layout).To(Equal(Layout{"go.kubebuilder.io/v2", "addon.example.com/v1"}))
+		Expect(config.Layout.String()).To(Equal("go.kubebuilder.io/v2,addon.example.com/v1"))
+	})
+
+	It("should marshal and unmarshal the hooks", func() {
+		config := Config{Version: Version3Alpha, Hooks: Hooks{
+			"create api": {Post: []string{"buf generate"}},
+		}}
+		b, err := config.Marshal()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(b)).To(Equal("hooks:\n  create api:\n    post:\n    - buf generate\nversion: 3-alpha\n"))
+		config = Config{}
+		Expect(config.Unmarshal(b)).To(Succeed())
+		Expect(config.Hooks["create api"].Post).To(Equal([]string{"buf generate"}))
+	})
+
+	It("should track the metadata of the resources", func() {
+		captain := GVK{Group: "crew", Version: "v1", Kind: "Captain"}
+		metadata := GVK{Group: "crew", Version: "v1", Kind: "Captain",
+			Plural: "captains", Scope: NamespacedScope, Domain: "crew.example.com", Path: "example.com/p/api/v1"}
+
+		By("Using config version 2")
+		config := Config{Version: Version2}
+		Expect(config.UpdateResource(metadata)).To(BeTrue())
+		Expect(config.Resources).To(Equal([]GVK{captain}))
+
+		By("Using config version 3-alpha")
+		config = Config{Version: Version3Alpha}
+		Expect(config.AddResource(metadata)).To(BeTrue())
+		Expect(config.Resources).To(Equal([]GVK{metadata}))
+
+		By("Recording a controller and webhooks")
+		controller := captain
+		controller.Controller = true
+		Expect(config.UpdateResource(controller)).To(BeTrue())
+		Expect(config.UpdateResource(controller)).To(BeFalse())
+		webhooks := captain
+		webhooks.Webhooks = &Webhooks{Defaulting: true}
+		Expect(config.UpdateResource(webhooks)).To(BeTrue())
+		webhooks.Webhooks = &Webhooks{Validation: true}
+		Expect(config.UpdateResource(webhooks)).To(BeTrue())
+
+		res, found := config.GetResource(captain)
+		Expect(found).To(BeTrue())
+		expected := metadata
+		expected.Controller = true
+		expected.Webhooks = &Webhooks{Defaulting: true, Validation: true}
+		Expect(res).To(Equal(expected))
+
+		b, err := config.Marshal()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(b)).To(Equal(`resources:
+- controller: true
+  domain: crew.example.com
+  group: crew
+  kind: Captain
+  path: example.com/p/api/v1
+  plural: captains
+  scope: Namespaced
+  version: v1
+  webhooks:
+    defaulting: true
+    validation: true
+version: 3-alpha
+`))
+	})
+
+	It("should remove resources and their webhooks", func() {
+		captain := GVK{Group: "crew", Version: "v1", Kind: "Captain", Webhooks: &Webhooks{Defaulting: true}}
+		firstMate := GVK{Group: "crew", Version: "v1", Kind: "FirstMate"}
+
+		By("Using config version 1")
+		config := Config{Version: Version1}
+		Expect(config.RemoveResource(captain)).To(BeFalse())
+
+		By("Using config version 3-alpha")
+		config = Config{Version: Version3Alpha, Resources: []GVK{captain, firstMate}}
+		Expect(config.RemoveWebhooks(captain)).To(BeTrue())
+		Expect(config.RemoveWebhooks(captain)).To(BeFalse())
+		Expect(config.Resources[0].Webhooks).To(BeNil())
+		Expect(config.RemoveResource(captain)).To(BeTrue())
+		Expect(config.RemoveResource(captain)).To(BeFalse())
+		Expect(config.Resources).To(Equal([]GVK{firstMate}))
+	})
 })
@@ -0,0 +1,406 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+// SchemaVersion is the JSON Schema draft the schemas are written in
+const SchemaVersion = "http://json-schema.org/draft-07/schema#"
+
+// JSON Schema types
+const (
+	ObjectType  = "object"
+	ArrayType   = "array"
+	StringType  = "string"
+	BooleanType = "boolean"
+	IntegerType = "integer"
+	NumberType  = "number"
+	NullType    = "null"
+)
+
+// Schema is the subset of JSON Schema used to describe the project config and the plugin configs
+// stored in its `plugins` field
+type Schema struct {
+	// Schema is the JSON Schema draft of a root schema
+	Schema string `json:"$schema,omitempty"`
+	// Title is a short description of the value
+	Title string `json:"title,omitempty"`
+	// Description explains the value
+	Description string `json:"description,omitempty"`
+
+	// Type is the JSON type of the value, any type is allowed if empty
+	Type string `json:"type,omitempty"`
+	// Enum lists the allowed string values
+	Enum []string `json:"enum,omitempty"`
+	// OneOf lists alternative schemas, the value must be valid against exactly one of them
+	OneOf []*Schema `json:"oneOf,omitempty"`
+
+	// Properties are the schemas of the known fields of an object
+	Properties map[string]*Schema `json:"properties,omitempty"`
+	// AdditionalProperties is the schema of the fields not listed in Properties,
+	// fields not listed are not allowed if it is a FalseSchema
+	AdditionalProperties *Schema `json:"additionalProperties,omitempty"`
+	// Items is the schema of the elements of an array
+	Items *Schema `json:"items,omitempty"`
+
+	// disallow makes this the boolean schema false, against which no value is valid
+	disallow bool
+}
+
+// FalseSchema returns the schema against which no value is valid, it is serialized as false
+func FalseSchema() *Schema {
+	return &Schema{disallow: true}
+}
+
+// schemaAlias has the fields of Schema without its custom JSON methods
+type schemaAlias Schema
+
+// MarshalJSON implements json.Marshaler
+func (s Schema) MarshalJSON() ([]byte, error) {
+	if s.disallow {
+		return []byte("false"), nil
+	}
+	return json.Marshal(schemaAlias(s))
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (s *Schema) UnmarshalJSON(b []byte) error {
+	switch string(bytes.TrimSpace(b)) {
+	case "false":
+		*s = Schema{disallow: true}
+		return nil
+	case "true":
+		*s = Schema{}
+		return nil
+	}
+	var alias schemaAlias
+	if err := json.Unmarshal(b, &alias); err != nil {
+		return err
+	}
+	*s = Schema(alias)
+	return nil
+}
+
+// SchemaProvider is implemented by the types which describe their own schema,
+// instead of the one generated from their Go type
+type SchemaProvider interface {
+	JSONSchema() *Schema
+}
+
+var schemaProviderType = reflect.TypeOf((*SchemaProvider)(nil)).Elem()
+
+// SchemaFor returns the schema of v, which may be a *Schema or a value of the Go type it is decoded into.
+// The schemas of structs are generated from their JSON field names and do not allow unknown fields,
+// like the strict unmarshalling of the project config.
+func SchemaFor(v interface{}) (*Schema, error) {
+	if s, isSchema := v.(*Schema); isSchema {
+		return s, nil
+	}
+	return schemaForType(reflect.TypeOf(v))
+}
+
+func schemaForType(t reflect.Type) (*Schema, error) {
+	if t == nil {
+		return &Schema{}, nil
+	}
+	if t.Implements(schemaProviderType) {
+		return reflect.Zero(t).Interface().(SchemaProvider).JSONSchema(), nil
+	}
+	if reflect.PtrTo(t).Implements(schemaProviderType) {
+		return reflect.New(t).Interface().(SchemaProvider).JSONSchema(), nil
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr:
+		return schemaForType(t.Elem())
+	case reflect.Interface:
+		return &Schema{}, nil
+	case reflect.String:
+		return &Schema{Type: StringType}, nil
+	case reflect.Bool:
+		return &Schema{Type: BooleanType}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: IntegerType}, nil
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: NumberType}, nil
+	case reflect.Slice, reflect.Array:
+		items, err := schemaForType(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return &Schema{Type: ArrayType, Items: items}, nil
+	case reflect.Map:
+		if t.Key().Kind() != reflect.String {
+			return nil, fmt.Errorf("unsupported map key type %s, only string keys are allowed", t.Key())
+		}
+		values, err := schemaForType(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return &Schema{Type: ObjectType, AdditionalProperties: values}, nil
+	case reflect.Struct:
+		s := &Schema{Type: ObjectType, Properties: make(map[string]*Schema), AdditionalProperties: FalseSchema()}
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if field.PkgPath != "" {
+				// Unexported fields are not serialized.
+				continue
+			}
+			name := strings.Split(field.Tag.Get("json"), ",")[0]
+			if name == "-" {
+				continue
+			}
+			if name == "" {
+				name = field.Name
+			}
+			property, err := schemaForType(field.Type)
+			if err != nil {
+				return nil, fmt.Errorf("field %s: %v", field.Name, err)
+			}
+			s.Properties[name] = property
+		}
+		return s, nil
+	default:
+		return nil, fmt.Errorf("unsupported type %s", t)
+	}
+}
+
+// JSONSchema implements SchemaProvider
+func (Layout) JSONSchema() *Schema {
+	return &Schema{
+		Description: "keys of the plugins that created the project, in the order they run",
+		OneOf: []*Schema{
+			{Type: StringType},
+			{Type: ArrayType, Items: &Schema{Type: StringType}},
+		},
+	}
+}
+
+// NewSchema returns the schema of the project config, with the given schemas of the plugin configs mapped by
+// plugin key. The configs of the plugins without a schema may be any object.
+func NewSchema(plugins map[string]*Schema) (*Schema, error) {
+	s, err := SchemaFor(Config{})
+	if err != nil {
+		return nil, err
+	}
+	s.Schema = SchemaVersion
+	s.Title = "Kubebuilder project config"
+	s.Description = "The PROJECT file at the root of a project scaffolded by kubebuilder"
+
+	s.Properties["version"].Enum = []string{Version1, Version2, Version3Alpha}
+	s.Properties["resources"].Items.Properties["scope"].Enum = []string{NamespacedScope, ClusterScope}
+	s.Properties["plugins"] = &Schema{
+		Description:          "plugin-specific configs mapped by plugin key",
+		Type:                 ObjectType,
+		Properties:           plugins,
+		AdditionalProperties: &Schema{Type: ObjectType},
+	}
+	return s, nil
+}
+
+// FieldError is a validation error of a field of the project config
+type FieldError struct {
+	// Field is the path of the field, ex. "resources[0].webhooks.defaulting", empty for the whole config
+	Field string `json:"field"`
+	// Message describes the error
+	Message string `json:"message"`
+}
+
+// Error implements error
+func (e FieldError) Error() string {
+	if e.Field == "" {
+		return e.Message
+	}
+	return fmt.Sprintf("%s: %s", e.Field, e.Message)
+}
+
+// Validate validates the YAML or JSON document b against the schema and returns the errors of every field.
+// The error is only set if b can not be parsed.
+func (s *Schema) Validate(b []byte) ([]FieldError, error) {
+	j, err := yaml.YAMLToJSON(b)
+	if err != nil {
+		return nil, err
+	}
+	var value interface{}
+	if err := json.Unmarshal(j, &value); err != nil {
+		return nil, err
+	}
+	if value == nil {
+		// An empty document is an empty object.
+		value = map[string]interface{}{}
+	}
+
+	var errs []FieldError
+	s.validate("", value, &errs)
+	return errs, nil
+}
+
+func (s *Schema) validate(path string, value interface{}, errs *[]FieldError) {
+	addError := func(format string, args ...interface{}) {
+		*errs = append(*errs, FieldError{Field: path, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if s.disallow {
+		addError("is not allowed")
+		return
+	}
+	if len(s.OneOf) != 0 {
+		s.validateOneOf(path, value, errs)
+		return
+	}
+	if s.Type != "" && !hasType(value, s.Type) {
+		addError("must be of type %s, found %s", s.Type, typeOf(value))
+		return
+	}
+	if len(s.Enum) != 0 {
+		str, isString := value.(string)
+		if !isString || !containsString(s.Enum, str) {
+			addError("must be one of %s", strings.Join(quote(s.Enum), ", "))
+			return
+		}
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			fieldPath := fieldPath(path, key)
+			if property, known := s.Properties[key]; known {
+				property.validate(fieldPath, v[key], errs)
+			} else if s.AdditionalProperties != nil {
+				if s.AdditionalProperties.disallow {
+					*errs = append(*errs, FieldError{Field: fieldPath, Message: "unknown field"})
+					continue
+				}
+				s.AdditionalProperties.validate(fieldPath, v[key], errs)
+			}
+		}
+	case []interface{}:
+		if s.Items != nil {
+			for i, item := range v {
+				s.Items.validate(fmt.Sprintf("%s[%d]", path, i), item, errs)
+			}
+		}
+	}
+}
+
+// validateOneOf reports the errors of the alternative with the type of value, if any,
+// or the allowed types otherwise
+func (s *Schema) validateOneOf(path string, value interface{}, errs *[]FieldError) {
+	var (
+		matches  int
+		types    []string
+		typeErrs []FieldError
+	)
+	for _, alternative := range s.OneOf {
+		var alternativeErrs []FieldError
+		alternative.validate(path, value, &alternativeErrs)
+		if len(alternativeErrs) == 0 {
+			matches++
+			continue
+		}
+		types = append(types, alternative.Type)
+		if alternative.Type != "" && hasType(value, alternative.Type) {
+			typeErrs = alternativeErrs
+		}
+	}
+
+	switch {
+	case matches == 1:
+	case matches > 1:
+		*errs = append(*errs, FieldError{Field: path, Message: "matches more than one of the allowed schemas"})
+	case typeErrs != nil:
+		*errs = append(*errs, typeErrs...)
+	default:
+		*errs = append(*errs, FieldError{Field: path,
+			Message: fmt.Sprintf("must be of type %s, found %s", strings.Join(types, " or "), typeOf(value))})
+	}
+}
+
+// identifierRegex matches the field names which are written after a dot in field paths
+var identifierRegex = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_-]*$`)
+
+// fieldPath returns the path of the field key of the object at path
+func fieldPath(path, key string) string {
+	switch {
+	case !identifierRegex.MatchString(key):
+		return fmt.Sprintf("%s[%q]", path, key)
+	case path == "":
+		return key
+	default:
+		return path + "." + key
+	}
+}
+
+// typeOf returns the JSON type of a value decoded by encoding/json
+func typeOf(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return NullType
+	case bool:
+		return BooleanType
+	case float64:
+		if v == float64(int64(v)) {
+			return IntegerType
+		}
+		return NumberType
+	case string:
+		return StringType
+	case []interface{}:
+		return ArrayType
+	default:
+		return ObjectType
+	}
+}
+
+// hasType returns true if value is of the JSON type t, integers are also numbers
+func hasType(value interface{}, t string) bool {
+	actual := typeOf(value)
+	return actual == t || (t == NumberType && actual == IntegerType)
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func quote(values []string) []string {
+	quoted := make([]string, 0, len(values))
+	for _, v := range values {
+		quoted = append(quoted, fmt.Sprintf("%q", v))
+	}
+	return quoted
+}
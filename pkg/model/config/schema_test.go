@@ -0,0 +1,119 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Schema", func() {
+	type replicas struct {
+		Min   int      `json:"min"`
+		Names []string `json:"names,omitempty"`
+	}
+
+	It("should generate the schema of a struct", func() {
+		s, err := SchemaFor(replicas{})
+		Expect(err).NotTo(HaveOccurred())
+		b, err := json.Marshal(s)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(b).To(MatchJSON(`{
+			"type": "object",
+			"properties": {
+				"min": {"type": "integer"},
+				"names": {"type": "array", "items": {"type": "string"}}
+			},
+			"additionalProperties": false
+		}`))
+	})
+
+	It("should return schemas as is and reject unsupported types", func() {
+		s := &Schema{Type: StringType}
+		Expect(SchemaFor(s)).To(BeIdenticalTo(s))
+
+		_, err := SchemaFor(map[int]string{})
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should unmarshal boolean schemas", func() {
+		var s Schema
+		Expect(json.Unmarshal([]byte(`{"type": "object", "additionalProperties": false}`), &s)).To(Succeed())
+		Expect(s.AdditionalProperties).To(Equal(FalseSchema()))
+	})
+
+	It("should report the errors of each field with its path", func() {
+		pluginSchema, err := SchemaFor(replicas{})
+		Expect(err).NotTo(HaveOccurred())
+		s, err := NewSchema(map[string]*Schema{"ship.example.com/v1": pluginSchema})
+		Expect(err).NotTo(HaveOccurred())
+
+		errs, err := s.Validate([]byte(`version: "3-alpha"
+layout:
+- go.kubebuilder.io/v3-alpha
+- 3
+resources:
+- kind: Captain
+  scope: Galaxy
+  webhooks:
+    defaulting: "yes"
+plugins:
+  ship.example.com/v1:
+    min: 1.5
+    max: 3
+  other.example.com/v1:
+    anything: true
+owner: me
+`))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(errs).To(Equal([]FieldError{
+			{Field: "layout[1]", Message: "must be of type string, found integer"},
+			{Field: "owner", Message: "unknown field"},
+			{Field: `plugins["ship.example.com/v1"].max`, Message: "unknown field"},
+			{Field: `plugins["ship.example.com/v1"].min`, Message: "must be of type integer, found number"},
+			{Field: "resources[0].scope", Message: `must be one of "Namespaced", "Cluster"`},
+			{Field: "resources[0].webhooks.defaulting", Message: "must be of type boolean, found string"},
+		}))
+	})
+
+	It("should accept valid configs", func() {
+		s, err := NewSchema(nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		c := Config{Version: Version3Alpha, Layout: Layout{"go.kubebuilder.io/v3-alpha"}, Domain: "example.com"}
+		c.Resources = []GVK{{Group: "ship", Version: "v1", Kind: "Captain", Scope: ClusterScope,
+			Webhooks: &Webhooks{Defaulting: true}}}
+		b, err := c.Marshal()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(s.Validate(b)).To(BeEmpty())
+		Expect(s.Validate(nil)).To(BeEmpty())
+	})
+
+	It("should match the published schema", func() {
+		s, err := NewSchema(nil)
+		Expect(err).NotTo(HaveOccurred())
+		b, err := json.Marshal(s)
+		Expect(err).NotTo(HaveOccurred())
+		published, err := ioutil.ReadFile(filepath.Join("..", "..", "..", "docs", "project.schema.json"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(b).To(MatchJSON(published), "run 'make generate-schema' to update docs/project.schema.json")
+	})
+})
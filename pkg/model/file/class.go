@@ -0,0 +1,107 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package file
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// Class groups the scaffolded files that can be forced to be overwritten if they already exist
+type Class string
+
+const (
+	// TypesClass are the Go types of the APIs
+	TypesClass Class = "types"
+
+	// ControllersClass are the controllers of the APIs
+	ControllersClass Class = "controllers"
+
+	// WebhooksClass are the webhooks of the APIs
+	WebhooksClass Class = "webhooks"
+
+	// SamplesClass are the sample custom resources
+	SamplesClass Class = "samples"
+
+	// ConfigClass are the rest of the kustomize manifests
+	ConfigClass Class = "config"
+
+	// OtherClass are the rest of the files, such as the Makefile or the Dockerfile
+	OtherClass Class = "other"
+
+	// AllClasses may be used instead of every class
+	AllClasses = "all"
+)
+
+// Classes are the available classes, in the order they are documented
+var Classes = []Class{TypesClass, ControllersClass, WebhooksClass, SamplesClass, ConfigClass, OtherClass}
+
+// ClassOf returns the class of the file at path
+func ClassOf(path string) Class {
+	path = filepath.ToSlash(filepath.Clean(path))
+	switch {
+	case strings.HasPrefix(path, "config/samples/"):
+		return SamplesClass
+	case strings.HasPrefix(path, "config/"):
+		return ConfigClass
+	case strings.HasSuffix(path, "_types.go"):
+		return TypesClass
+	case strings.HasSuffix(path, "_controller.go"):
+		return ControllersClass
+	case strings.HasSuffix(path, "_webhook.go"):
+		return WebhooksClass
+	default:
+		return OtherClass
+	}
+}
+
+// ParseClasses parses the names of classes, where AllClasses stands for every class
+func ParseClasses(names []string) ([]Class, error) {
+	classes := make([]Class, 0, len(names))
+	for _, name := range names {
+		if name == AllClasses {
+			return Classes, nil
+		}
+
+		class := Class(name)
+		if !class.isValid() {
+			return nil, fmt.Errorf("unknown file class %q, possible values: %s", name, classNames())
+		}
+		classes = append(classes, class)
+	}
+	return classes, nil
+}
+
+// isValid returns true if c is one of the available classes
+func (c Class) isValid() bool {
+	for _, class := range Classes {
+		if c == class {
+			return true
+		}
+	}
+	return false
+}
+
+// classNames returns the names that can be parsed as classes, separated by commas
+func classNames() string {
+	names := make([]string, 0, len(Classes)+1)
+	for _, class := range Classes {
+		names = append(names, string(class))
+	}
+	return strings.Join(append(names, AllClasses), ", ")
+}
@@ -18,6 +18,9 @@ package file
 
 import (
 	"errors"
+	"fmt"
+	"os"
+	"strings"
 )
 
 // validateError is a wrapper error that will be used for errors returned by RequiresValidation.Validate
@@ -59,3 +62,39 @@ func (e setTemplateDefaultsError) Unwrap() error {
 func IsSetTemplateDefaultsError(err error) bool {
 	return errors.As(err, &setTemplateDefaultsError{})
 }
+
+// conflictsError is returned if some files are expected not to exist but they do
+type conflictsError struct {
+	conflicts []Conflict
+}
+
+// NewConflictsError returns an error for existing files that were expected not to exist
+func NewConflictsError(conflicts []Conflict) error {
+	return conflictsError{conflicts}
+}
+
+// Error implements error interface
+func (e conflictsError) Error() string {
+	var sb strings.Builder
+	sb.WriteString("failed to create files that already exist, force their classes to overwrite them:\n")
+	for _, c := range e.conflicts {
+		fmt.Fprintf(&sb, "  %s (%s)\n", c.Path, c.Class)
+	}
+	for _, c := range e.conflicts {
+		if c.Diff != "" {
+			sb.WriteString("\n")
+			sb.WriteString(c.Diff)
+		}
+	}
+	return strings.TrimSuffix(sb.String(), "\n")
+}
+
+// Unwrap implements Wrapper interface, so that errors.Is(err, os.ErrExist) holds
+func (e conflictsError) Unwrap() error {
+	return os.ErrExist
+}
+
+// IsConflictsError checks if the error is because some files already existed when expected not to
+func IsConflictsError(err error) bool {
+	return errors.As(err, &conflictsError{})
+}
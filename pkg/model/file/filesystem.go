@@ -0,0 +1,41 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package file
+
+import (
+	"github.com/spf13/afero"
+)
+
+// Filesystem is the destination of scaffolded files
+type Filesystem struct {
+	// FS is the file system files are read from and written to
+	FS afero.Fs
+
+	// Report collects the operation performed on each file, it may be nil
+	Report *Report
+
+	// Force are the classes of files that are overwritten if they already exist when they are expected not to
+	Force []Class
+
+	// Templates is the file system the overrides of the template bodies are read from, it may be nil
+	Templates afero.Fs
+}
+
+// NewOSFilesystem returns a Filesystem that writes to the OS file system and doesn't report changes
+func NewOSFilesystem() Filesystem {
+	return Filesystem{FS: afero.NewOsFs()}
+}
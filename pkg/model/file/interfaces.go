@@ -55,6 +55,23 @@ type Inserter interface {
 	GetCodeFragments() CodeFragmentsMap
 }
 
+// GoInserter is an Inserter of Go code that locates where to insert its code fragments by analyzing the code,
+// so that they are inserted even if the markers were removed
+type GoInserter interface {
+	Inserter
+	// GetInsertionPoints returns where the code fragments of each marker are inserted, the code fragments of
+	// the markers without insertion point are inserted at the marker
+	GetInsertionPoints() map[Marker]InsertionPoint
+}
+
+// YAMLInserter is a file builder that inserts entries into the top-level lists of a YAML document,
+// leaving the rest of the document and its comments untouched
+type YAMLInserter interface {
+	Builder
+	// GetEntries returns the entries to insert
+	GetEntries() []YAMLEntry
+}
+
 // HasDomain allows the domain to be used on a template
 type HasDomain interface {
 	// InjectDomain sets the template domain
@@ -59,3 +59,18 @@ type CodeFragments []string
 
 // CodeFragmentsMap binds Markers and CodeFragments together
 type CodeFragmentsMap map[Marker]CodeFragments
+
+// InsertionPoint locates where the code fragments of a marker are inserted into a Go file by analyzing its code
+// When the marker is found where the code fragments are inserted, they are inserted at the marker instead
+type InsertionPoint struct {
+	// Imports inserts the code fragments into the import declaration
+	Imports bool
+
+	// Func is the function whose body the code fragments are inserted into
+	// It may also be a function called with a function literal, such as BeforeSuite, whose body is used instead
+	Func string
+
+	// Before is the beginning of the statement of the function body the code fragments are inserted before,
+	// if empty they are inserted at the end of the body
+	Before string
+}
@@ -0,0 +1,175 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package file
+
+import (
+	"sort"
+	"sync"
+)
+
+// Operation describes what the scaffolding machinery did to a file
+type Operation string
+
+const (
+	// Created means that the file did not exist and was created
+	Created Operation = "created"
+
+	// Overwritten means that the file existed and its contents were replaced
+	Overwritten Operation = "overwritten"
+
+	// Skipped means that the file existed and was left untouched
+	Skipped Operation = "skipped"
+
+	// InsertedInto means that code fragments were inserted into the existing file by an Inserter
+	InsertedInto Operation = "inserted into"
+
+	// Deleted means that the file existed and was removed
+	Deleted Operation = "deleted"
+
+	// RemovedFrom means that code fragments previously inserted by an Inserter were removed from the existing file
+	RemovedFrom Operation = "removed from"
+
+	// Merged means that the changes of the scaffold were merged into the existing file, which may have conflicts
+	Merged Operation = "merged"
+)
+
+// Change records an operation performed on a file
+type Change struct {
+	// Path is the file the operation was performed on
+	Path string `json:"path"`
+
+	// Operation is what was done to the file
+	Operation Operation `json:"operation"`
+
+	// Insertions are the code fragments inserted by Inserters, or removed from the file if the operation
+	// is RemovedFrom, if any
+	Insertions []Insertion `json:"insertions,omitempty"`
+}
+
+// Insertion records the code fragments inserted at a marker
+type Insertion struct {
+	// Marker is the marker the fragments were inserted at
+	Marker string `json:"marker"`
+
+	// Fragments are the inserted code fragments
+	Fragments []string `json:"fragments"`
+}
+
+// Conflict records an existing file that was expected not to exist
+type Conflict struct {
+	// Path is the existing file
+	Path string `json:"path"`
+
+	// Class is the class of the file, which can be forced to be overwritten
+	Class Class `json:"class"`
+
+	// Diff is the unified diff from the existing file to the scaffolded one
+	Diff string `json:"diff,omitempty"`
+}
+
+// Report collects the changes performed on files while scaffolding, the conflicts found, the commands
+// run afterwards and the manual steps left to the user
+// It is safe for concurrent use
+type Report struct {
+	mu          sync.Mutex
+	changes     []Change
+	conflicts   []Conflict
+	commands    []string
+	manualSteps []string
+}
+
+// Add records a change
+func (r *Report) Add(change Change) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.changes = append(r.changes, change)
+}
+
+// Changes returns the recorded changes in the order they were added
+func (r *Report) Changes() []Change {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	changes := make([]Change, len(r.changes))
+	copy(changes, r.changes)
+	return changes
+}
+
+// Sort sorts the recorded changes and conflicts by path, keeping the order in which the ones of
+// the same path were added
+func (r *Report) Sort() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	sort.SliceStable(r.changes, func(i, j int) bool { return r.changes[i].Path < r.changes[j].Path })
+	sort.SliceStable(r.conflicts, func(i, j int) bool { return r.conflicts[i].Path < r.conflicts[j].Path })
+}
+
+// AddConflict records a conflict
+func (r *Report) AddConflict(conflict Conflict) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.conflicts = append(r.conflicts, conflict)
+}
+
+// Conflicts returns the recorded conflicts in the order they were found
+func (r *Report) Conflicts() []Conflict {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	conflicts := make([]Conflict, len(r.conflicts))
+	copy(conflicts, r.conflicts)
+	return conflicts
+}
+
+// AddCommand records a command run after scaffolding
+func (r *Report) AddCommand(command string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.commands = append(r.commands, command)
+}
+
+// Commands returns the recorded commands in the order they were run
+func (r *Report) Commands() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	commands := make([]string, len(r.commands))
+	copy(commands, r.commands)
+	return commands
+}
+
+// AddManualStep records a step that could not be performed automatically and is left to the user
+func (r *Report) AddManualStep(step string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.manualSteps = append(r.manualSteps, step)
+}
+
+// ManualSteps returns the recorded manual steps in the order they were added
+func (r *Report) ManualSteps() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	manualSteps := make([]string, len(r.manualSteps))
+	copy(manualSteps, r.manualSteps)
+	return manualSteps
+}
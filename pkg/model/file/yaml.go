@@ -0,0 +1,37 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package file
+
+// YAMLEntry is an entry of a top-level list of a YAML document, such as the resources of a kustomization file
+type YAMLEntry struct {
+	// Key is the top-level key of the list, which is added if missing
+	Key string
+
+	// Value is the entry
+	Value string
+
+	// Commented entries are inserted commented out, so that users enable them by uncommenting them
+	Commented bool
+
+	// Toggle switches an existing entry to the commented state of this one, otherwise existing entries
+	// are left as they are
+	Toggle bool
+
+	// Marker is where the entry is inserted if it is found in the list, otherwise the entry is appended to the list
+	// It is optional
+	Marker Marker
+}
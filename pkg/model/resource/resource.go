@@ -62,6 +62,19 @@ func (r *Resource) GVK() config.GVK {
 	}
 }
 
+// Metadata returns the resource information tracked in the configuration file of v3+ projects
+func (r *Resource) Metadata() config.GVK {
+	gvk := r.GVK()
+	gvk.Plural = r.Plural
+	gvk.Scope = config.ClusterScope
+	if r.Namespaced {
+		gvk.Scope = config.NamespacedScope
+	}
+	gvk.Domain = r.Domain
+	gvk.Path = r.Package
+	return gvk
+}
+
 func wrapKey(key string) string {
 	return fmt.Sprintf("%%[%s]", key)
 }
@@ -70,6 +70,32 @@ var _ = Describe("Resource", func() {
 			Expect(resource.Domain).To(Equal("crew.test.io"))
 		})
 
+		It("should return the metadata tracked in the project configuration", func() {
+			options := &Options{Group: "crew", Version: "v1", Kind: "FirstMate"}
+			Expect(options.Validate()).To(Succeed())
+
+			resource := options.NewResource(
+				&config.Config{
+					Version: config.Version3Alpha,
+					Domain:  "test.io",
+					Repo:    "test",
+				},
+				true,
+			)
+			Expect(resource.Metadata()).To(Equal(config.GVK{
+				Group:   "crew",
+				Version: "v1",
+				Kind:    "FirstMate",
+				Plural:  "firstmates",
+				Scope:   config.ClusterScope,
+				Domain:  "crew.test.io",
+				Path:    path.Join("test", "api", "v1"),
+			}))
+
+			resource.Namespaced = true
+			Expect(resource.Metadata().Scope).To(Equal(config.NamespacedScope))
+		})
+
 		It("should default the Plural by pluralizing the Kind", func() {
 			singleGroupConfig := &config.Config{
 				Version: config.Version2,
@@ -0,0 +1,110 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package external
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/afero"
+
+	"sigs.k8s.io/kubebuilder/internal/cmdutil"
+)
+
+// ExecutablePrefix is the prefix of external plugin executables found in $PATH
+const ExecutablePrefix = "kubebuilder-plugin-"
+
+// DefaultPluginsDir returns the directory where external plugins are looked up besides $PATH,
+// $XDG_CONFIG_HOME/kubebuilder/plugins, defaulting to ~/.config/kubebuilder/plugins. The variables
+// are looked up with getenv.
+func DefaultPluginsDir(getenv func(string) string) (string, error) {
+	configDir, err := cmdutil.UserConfigDir(getenv)
+	if err != nil {
+		return "", fmt.Errorf("unable to find the plugins directory: %v", err)
+	}
+	return filepath.Join(configDir, "plugins"), nil
+}
+
+// Discover finds external plugin executables in fs and queries their metadata. Every executable in pluginsDir
+// and every executable in the directories listed in path, the value of $PATH, whose name starts with
+// ExecutablePrefix is considered a plugin. Executables that are found first shadow later ones with the same
+// name, pluginsDir taking precedence over path.
+//
+// Plugins that fail to return valid metadata in time are not returned, instead an error describing them is.
+// The standard error of the executables goes to stderr.
+func Discover(fs afero.Fs, pluginsDir, path string, stderr io.Writer) ([]*Plugin, error) {
+	paths := findExecutables(fs, pluginsDir, "")
+	for _, dir := range filepath.SplitList(path) {
+		paths = append(paths, findExecutables(fs, dir, ExecutablePrefix)...)
+	}
+
+	plugins := make([]*Plugin, 0, len(paths))
+	seen := make(map[string]bool, len(paths))
+	var errs []string
+	for _, path := range paths {
+		name := filepath.Base(path)
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+
+		p, err := New(path, stderr)
+		if err != nil {
+			errs = append(errs, err.Error())
+			continue
+		}
+		plugins = append(plugins, p)
+	}
+
+	if len(errs) != 0 {
+		return plugins, fmt.Errorf("unable to load external plugins:\n  %s", strings.Join(errs, "\n  "))
+	}
+	return plugins, nil
+}
+
+// findExecutables returns the executable regular files in dir of fs whose name starts with prefix,
+// missing or unreadable directories are ignored
+func findExecutables(fs afero.Fs, dir, prefix string) []string {
+	if dir == "" {
+		return nil
+	}
+
+	infos, err := afero.ReadDir(fs, dir)
+	if err != nil {
+		return nil
+	}
+
+	var paths []string
+	for _, info := range infos {
+		if !strings.HasPrefix(info.Name(), prefix) {
+			continue
+		}
+
+		// Stat the path instead of using info so that symbolic links are followed
+		path := filepath.Join(dir, info.Name())
+		if info, err = fs.Stat(path); err != nil {
+			continue
+		}
+		if !info.Mode().IsRegular() || info.Mode().Perm()&0111 == 0 {
+			continue
+		}
+		paths = append(paths, path)
+	}
+	return paths
+}
@@ -0,0 +1,65 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package external
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os/exec"
+)
+
+// call runs the plugin executable at path in dir, sending req through its standard input
+// and decoding the Response it writes to its standard output, its standard error goes to stderr.
+// The executable is killed if ctx is done before it exits.
+func call(ctx context.Context, path, dir string, stderr io.Writer, req Request) (*Response, error) {
+	req.APIVersion = ProtocolVersion
+
+	in, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("unable to encode %q request: %v", req.Command, err)
+	}
+
+	var out bytes.Buffer
+	cmd := exec.CommandContext(ctx, path) // nolint:gosec
+	cmd.Dir = dir
+	cmd.Stdin = bytes.NewReader(in)
+	cmd.Stdout = &out
+	cmd.Stderr = stderr
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return nil, fmt.Errorf("external plugin %s timed out running %q", path, req.Command)
+		}
+		return nil, fmt.Errorf("external plugin %s failed to run %q: %v", path, req.Command, err)
+	}
+
+	var res Response
+	if err := json.Unmarshal(out.Bytes(), &res); err != nil {
+		return nil, fmt.Errorf("external plugin %s returned an invalid response to %q: %v", path, req.Command, err)
+	}
+	if res.APIVersion != ProtocolVersion {
+		return nil, fmt.Errorf("external plugin %s speaks protocol %q, expected %q",
+			path, res.APIVersion, ProtocolVersion)
+	}
+	if res.Error != "" {
+		return nil, fmt.Errorf("external plugin %s: %s", path, res.Error)
+	}
+
+	return &res, nil
+}
@@ -0,0 +1,185 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package external
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/spf13/afero"
+	"github.com/spf13/pflag"
+
+	"sigs.k8s.io/kubebuilder/pkg/model/config"
+	"sigs.k8s.io/kubebuilder/pkg/model/file"
+	"sigs.k8s.io/kubebuilder/pkg/plugin"
+)
+
+func TestExternal(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "External plugin suite")
+}
+
+// fakePlugin answers the metadata command and scaffolds a single file for any other command,
+// echoing the request it received as the file contents
+const fakePlugin = `#!/bin/sh
+req=$(cat)
+case "$req" in
+*'"command":"metadata"'*)
+  echo '{"apiVersion":"v1alpha1","command":"metadata","metadata":{"name":"fake.example.com","version":"v1-alpha",` +
+	`"supportedProjectVersions":["3-alpha"],"subcommands":{"init":{"description":"fake init",` +
+	`"flags":[{"name":"owner","usage":"owner name"},{"name":"verbose","type":"bool"}]}}}}'
+  ;;
+*)
+  echo '{"apiVersion":"v1alpha1","command":"init","files":[{"path":"hack/request.json",` +
+	`"contents":"done"}],"config":{"version":"3-alpha","domain":"example.com"}}'
+  ;;
+esac
+`
+
+const brokenPlugin = `#!/bin/sh
+echo '{"apiVersion":"v0"}'
+`
+
+const hangingPlugin = `#!/bin/sh
+echo "hanging" >&2
+exec sleep 60
+`
+
+var _ = Describe("External plugins", func() {
+	var dir string
+
+	writeExecutable := func(name, contents string) string {
+		path := filepath.Join(dir, name)
+		Expect(ioutil.WriteFile(path, []byte(contents), 0700)).To(Succeed())
+		return path
+	}
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "kubebuilder-external-")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		Expect(os.RemoveAll(dir)).To(Succeed())
+	})
+
+	Describe("New", func() {
+		It("should build a plugin from its metadata", func() {
+			p, err := New(writeExecutable("fake", fakePlugin), GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(p.Name()).To(Equal("fake.example.com"))
+			Expect(p.Version()).To(Equal(plugin.Version{Number: 1, Stage: plugin.AlphaStage}))
+			Expect(p.SupportedProjectVersions()).To(Equal([]string{"3-alpha"}))
+		})
+
+		It("should fail if the plugin does not return its metadata in time", func() {
+			timeout := metadataTimeout
+			metadataTimeout = 100 * time.Millisecond
+			defer func() { metadataTimeout = timeout }()
+
+			var stderr bytes.Buffer
+			_, err := New(writeExecutable("hanging", hangingPlugin), &stderr)
+			Expect(err).To(MatchError(ContainSubstring("timed out")))
+			Expect(stderr.String()).To(Equal("hanging\n"))
+		})
+
+		It("should fail if the plugin speaks another protocol version", func() {
+			_, err := New(writeExecutable("broken", brokenPlugin), GinkgoWriter)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("Discover", func() {
+		It("should find prefixed executables in $PATH", func() {
+			writeExecutable(ExecutablePrefix+"fake", fakePlugin)
+			writeExecutable("not-a-plugin", brokenPlugin)
+
+			plugins, err := Discover(afero.NewOsFs(), "", dir, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(plugins).To(HaveLen(1))
+			Expect(plugins[0].Path()).To(Equal(filepath.Join(dir, ExecutablePrefix+"fake")))
+		})
+
+		It("should find every executable in the plugins directory", func() {
+			writeExecutable("fake", fakePlugin)
+
+			plugins, err := Discover(afero.NewOsFs(), dir, "", GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(plugins).To(HaveLen(1))
+		})
+
+		It("should report broken plugins and return the valid ones", func() {
+			writeExecutable("fake", fakePlugin)
+			writeExecutable("broken", brokenPlugin)
+
+			plugins, err := Discover(afero.NewOsFs(), dir, "", GinkgoWriter)
+			Expect(err).To(HaveOccurred())
+			Expect(plugins).To(HaveLen(1))
+		})
+	})
+
+	Describe("subcommands", func() {
+		var (
+			p   *Plugin
+			cfg *config.Config
+			ctx plugin.Context
+		)
+
+		BeforeEach(func() {
+			var err error
+			p, err = New(writeExecutable("fake", fakePlugin), GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+
+			cfg = &config.Config{Version: "3-alpha"}
+			ctx = plugin.Context{CommandName: "kubebuilder", Filesystem: afero.NewMemMapFs(), Report: &file.Report{}}
+		})
+
+		It("should bind the declared flags and write the returned files and config", func() {
+			init := p.GetInitPlugin()
+			init.UpdateContext(&ctx)
+			Expect(ctx.Description).To(Equal("fake init"))
+
+			fs := pflag.NewFlagSet("init", pflag.ContinueOnError)
+			init.BindFlags(fs)
+			Expect(fs.Parse([]string{"--owner", "me", "--verbose"})).To(Succeed())
+			init.InjectConfig(cfg)
+
+			Expect(init.Run()).To(Succeed())
+			contents, err := afero.ReadFile(ctx.Filesystem, filepath.Join("hack", "request.json"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(contents)).To(Equal("done"))
+			Expect(ctx.Report.Changes()).To(Equal([]file.Change{
+				{Path: filepath.Join("hack", "request.json"), Operation: file.Created},
+			}))
+			Expect(cfg.Domain).To(Equal("example.com"))
+		})
+
+		It("should not return subcommands the plugin does not implement", func() {
+			Expect(p.GetCreateAPIPlugin()).To(BeNil())
+			Expect(p.GetCreateWebhookPlugin()).To(BeNil())
+			Expect(p.GetEditPlugin()).To(BeNil())
+		})
+	})
+})
@@ -0,0 +1,316 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package external
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/pflag"
+
+	"sigs.k8s.io/kubebuilder/internal/cmdutil"
+	"sigs.k8s.io/kubebuilder/pkg/model/config"
+	"sigs.k8s.io/kubebuilder/pkg/model/file"
+	"sigs.k8s.io/kubebuilder/pkg/plugin"
+	"sigs.k8s.io/kubebuilder/pkg/plugin/internal/filesystem"
+	"sigs.k8s.io/kubebuilder/pkg/plugin/scaffold"
+)
+
+// Plugin adapts an external plugin executable to the plugin interfaces
+type Plugin struct {
+	// path is the location of the plugin executable
+	path     string
+	metadata Metadata
+	version  plugin.Version
+}
+
+var (
+	_ plugin.Base                      = &Plugin{}
+	_ plugin.InitPluginGetter          = &Plugin{}
+	_ plugin.CreateAPIPluginGetter     = &Plugin{}
+	_ plugin.CreateWebhookPluginGetter = &Plugin{}
+	_ plugin.EditPluginGetter          = &Plugin{}
+	_ plugin.ConfigSchemaGetter        = &Plugin{}
+)
+
+// metadataTimeout is how long an external plugin executable may take to return its metadata
+var metadataTimeout = 5 * time.Second
+
+// New queries the metadata of the external plugin executable at path and returns its adapter.
+// The standard error of the executable goes to stderr.
+func New(path string, stderr io.Writer) (*Plugin, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), metadataTimeout)
+	defer cancel()
+
+	res, err := call(ctx, path, "", stderr, Request{Command: MetadataCommand})
+	if err != nil {
+		return nil, err
+	}
+	if res.Metadata == nil {
+		return nil, fmt.Errorf("external plugin %s returned no metadata", path)
+	}
+
+	if err := plugin.ValidateName(res.Metadata.Name); err != nil {
+		return nil, fmt.Errorf("external plugin %s has an invalid name: %v", path, err)
+	}
+	version, err := plugin.ParseVersion(res.Metadata.Version)
+	if err != nil {
+		return nil, fmt.Errorf("external plugin %s has an invalid version: %v", path, err)
+	}
+	if len(res.Metadata.SupportedProjectVersions) == 0 {
+		return nil, fmt.Errorf("external plugin %s supports no project versions", path)
+	}
+
+	return &Plugin{path: path, metadata: *res.Metadata, version: version}, nil
+}
+
+// Name implements plugin.Base
+func (p Plugin) Name() string {
+	return p.metadata.Name
+}
+
+// Version implements plugin.Base
+func (p Plugin) Version() plugin.Version {
+	return p.version
+}
+
+// SupportedProjectVersions implements plugin.Base
+func (p Plugin) SupportedProjectVersions() []string {
+	return p.metadata.SupportedProjectVersions
+}
+
+// Path returns the location of the plugin executable
+func (p Plugin) Path() string {
+	return p.path
+}
+
+// GetInitPlugin implements plugin.InitPluginGetter
+func (p Plugin) GetInitPlugin() plugin.Init {
+	return p.newSubcommand(InitCommand)
+}
+
+// GetCreateAPIPlugin implements plugin.CreateAPIPluginGetter
+func (p Plugin) GetCreateAPIPlugin() plugin.CreateAPI {
+	return p.newSubcommand(CreateAPICommand)
+}
+
+// GetCreateWebhookPlugin implements plugin.CreateWebhookPluginGetter
+func (p Plugin) GetCreateWebhookPlugin() plugin.CreateWebhook {
+	return p.newSubcommand(CreateWebhookCommand)
+}
+
+// GetEditPlugin implements plugin.EditPluginGetter
+func (p Plugin) GetEditPlugin() plugin.Edit {
+	return p.newSubcommand(EditCommand)
+}
+
+// GetConfigSchema implements plugin.ConfigSchemaGetter
+func (p Plugin) GetConfigSchema() interface{} {
+	if p.metadata.ConfigSchema == nil {
+		return nil
+	}
+	return p.metadata.ConfigSchema
+}
+
+// newSubcommand returns a nil subcommand if the plugin does not implement command,
+// which makes the CLI skip the plugin
+func (p Plugin) newSubcommand(command string) plugin.GenericSubcommand {
+	if _, ok := p.metadata.Subcommands[command]; !ok {
+		return nil
+	}
+	return &subcommand{plugin: p, command: command}
+}
+
+// subcommand forwards a single command to the external plugin executable
+type subcommand struct {
+	plugin  Plugin
+	command string
+
+	config *config.Config
+	// ctx is the runtime context provided by the CLI.
+	ctx plugin.Context
+	// flags is the flag set the plugin flags were bound to.
+	flags *pflag.FlagSet
+}
+
+var (
+	_ plugin.GenericSubcommand = &subcommand{}
+	_ cmdutil.RunOptions       = &subcommand{}
+)
+
+func (s *subcommand) metadata() (SubcommandMetadata, bool) {
+	meta, ok := s.plugin.metadata.Subcommands[s.command]
+	return meta, ok
+}
+
+func (s *subcommand) UpdateContext(ctx *plugin.Context) {
+	if meta, ok := s.metadata(); ok {
+		ctx.Description = meta.Description
+		ctx.Examples = strings.ReplaceAll(meta.Examples, "{{ .CommandName }}", ctx.CommandName)
+	}
+	s.ctx = *ctx
+}
+
+func (s *subcommand) BindFlags(fs *pflag.FlagSet) {
+	s.flags = fs
+
+	meta, _ := s.metadata()
+	for _, f := range meta.Flags {
+		switch f.Type {
+		case BoolFlag:
+			fs.Bool(f.Name, f.Default == "true", f.Usage)
+		default:
+			fs.String(f.Name, f.Default, f.Usage)
+		}
+	}
+}
+
+func (s *subcommand) InjectConfig(c *config.Config) {
+	s.config = c
+}
+
+func (s *subcommand) Run() error {
+	return cmdutil.Run(s, s.ctx)
+}
+
+func (s *subcommand) Validate() error {
+	return nil
+}
+
+func (s *subcommand) GetScaffolder() (scaffold.Scaffolder, error) {
+	req := Request{
+		Command: s.command,
+		Flags:   make(map[string]string),
+		Context: RequestContext{CommandName: s.ctx.CommandName, DryRun: s.ctx.DryRun},
+		Config:  s.config,
+	}
+	meta, _ := s.metadata()
+	for _, f := range meta.Flags {
+		if flag := s.flags.Lookup(f.Name); flag != nil {
+			req.Flags[f.Name] = flag.Value.String()
+		}
+	}
+
+	return &scaffolder{
+		path:    s.plugin.path,
+		dir:     s.ctx.WorkingDirectory,
+		stderr:  s.ctx.Stderr,
+		request: req,
+		config:  s.config,
+		fs:      file.NewOSFilesystem(),
+	}, nil
+}
+
+func (s *subcommand) PostScaffold() error {
+	return nil
+}
+
+// scaffolder writes the files returned by an external plugin
+type scaffolder struct {
+	path string
+	// dir is the directory the plugin runs in and stderr receives its errors
+	dir     string
+	stderr  io.Writer
+	request Request
+	config  *config.Config
+	fs      file.Filesystem
+}
+
+var _ scaffold.Scaffolder = &scaffolder{}
+
+// InjectFS implements scaffold.Scaffolder
+func (s *scaffolder) InjectFS(fs file.Filesystem) {
+	s.fs = fs
+}
+
+// Scaffold implements scaffold.Scaffolder
+func (s *scaffolder) Scaffold() error {
+	res, err := call(context.Background(), s.path, s.dir, s.stderr, s.request)
+	if err != nil {
+		return err
+	}
+
+	fs := filesystem.New(filesystem.AferoFs(s.fs.FS))
+	for _, f := range res.Files {
+		if err := s.writeFile(fs, f); err != nil {
+			return err
+		}
+	}
+
+	if res.Config != nil {
+		// Plugins may omit the fields managed by the CLI
+		version, layout := s.config.Version, s.config.Layout
+		*s.config = *res.Config
+		if s.config.Version == "" {
+			s.config.Version = version
+		}
+		if len(s.config.Layout) == 0 {
+			s.config.Layout = layout
+		}
+	}
+
+	return nil
+}
+
+func (s *scaffolder) writeFile(fs filesystem.FileSystem, f file.File) error {
+	path := filepath.Clean(f.Path)
+	if filepath.IsAbs(path) || path == ".." || strings.HasPrefix(path, ".."+string(filepath.Separator)) {
+		return fmt.Errorf("external plugin %s returned a file outside the project: %s", s.path, f.Path)
+	}
+
+	// Check if the file to write already exists
+	exists, err := fs.Exists(path)
+	if err != nil {
+		return err
+	}
+	operation := file.Created
+	if exists {
+		switch f.IfExistsAction {
+		case file.Overwrite:
+			operation = file.Overwritten
+		case file.Skip:
+			s.record(path, file.Skipped)
+			return nil
+		case file.Error:
+			return fmt.Errorf("failed to create %s: file already exists", path)
+		default:
+			return fmt.Errorf("unknown behavior if file exists (%d) for %s", f.IfExistsAction, path)
+		}
+	}
+
+	writer, err := fs.Create(path)
+	if err != nil {
+		return err
+	}
+
+	if _, err = writer.Write([]byte(f.Contents)); err != nil {
+		return err
+	}
+
+	s.record(path, operation)
+	return nil
+}
+
+func (s *scaffolder) record(path string, op file.Operation) {
+	if s.fs.Report != nil {
+		s.fs.Report.Add(file.Change{Path: path, Operation: op})
+	}
+}
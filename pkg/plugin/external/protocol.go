@@ -0,0 +1,143 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package external implements an adapter for plugins that run out of process.
+//
+// An external plugin is an executable that speaks a JSON protocol over its standard input and output:
+// kubebuilder writes a single Request to the plugin's standard input and the plugin must write a single
+// Response to its standard output before exiting. Anything written to the standard error is shown to the user.
+package external
+
+import (
+	"sigs.k8s.io/kubebuilder/pkg/model/config"
+	"sigs.k8s.io/kubebuilder/pkg/model/file"
+)
+
+// ProtocolVersion is the version of the protocol spoken with external plugins
+const ProtocolVersion = "v1alpha1"
+
+// Commands that can be requested to an external plugin
+const (
+	// MetadataCommand requests the plugin Metadata
+	MetadataCommand = "metadata"
+	// InitCommand requests the plugin to initialize a project
+	InitCommand = "init"
+	// CreateAPICommand requests the plugin to scaffold an API
+	CreateAPICommand = "create api"
+	// CreateWebhookCommand requests the plugin to scaffold a webhook
+	CreateWebhookCommand = "create webhook"
+	// EditCommand requests the plugin to edit the project configuration
+	EditCommand = "edit"
+)
+
+// Request is sent to an external plugin through its standard input
+type Request struct {
+	// APIVersion is the protocol version, always ProtocolVersion
+	APIVersion string `json:"apiVersion"`
+
+	// Command is the requested operation
+	Command string `json:"command"`
+
+	// Flags contains the value of every flag declared by the plugin for Command
+	Flags map[string]string `json:"flags,omitempty"`
+
+	// Context is the runtime context of the command
+	Context RequestContext `json:"context,omitempty"`
+
+	// Config is the project configuration
+	Config *config.Config `json:"config,omitempty"`
+}
+
+// RequestContext is the serializable subset of plugin.Context sent to external plugins
+type RequestContext struct {
+	// CommandName is the name of the CLI binary
+	CommandName string `json:"commandName,omitempty"`
+
+	// DryRun is set if the files returned by the plugin will not be written to disk
+	DryRun bool `json:"dryRun,omitempty"`
+}
+
+// Response is returned by an external plugin through its standard output
+type Response struct {
+	// APIVersion is the protocol version, must be ProtocolVersion
+	APIVersion string `json:"apiVersion"`
+
+	// Command is the operation this response answers to
+	Command string `json:"command"`
+
+	// Metadata describes the plugin, only returned for MetadataCommand
+	Metadata *Metadata `json:"metadata,omitempty"`
+
+	// Files are the files to write, relative to the project root
+	Files []file.File `json:"files,omitempty"`
+
+	// Config is the updated project configuration, if nil the configuration is left unchanged
+	Config *config.Config `json:"config,omitempty"`
+
+	// Error is set if the plugin failed to run the command
+	Error string `json:"error,omitempty"`
+}
+
+// Metadata describes an external plugin
+type Metadata struct {
+	// Name is the fully qualified plugin name, e.g. "ourcorp.example.com"
+	Name string `json:"name"`
+
+	// Version is the plugin version, e.g. "v1-alpha"
+	Version string `json:"version"`
+
+	// SupportedProjectVersions lists the project versions the plugin supports
+	SupportedProjectVersions []string `json:"supportedProjectVersions"`
+
+	// Subcommands maps each implemented command to its help text and flags
+	Subcommands map[string]SubcommandMetadata `json:"subcommands"`
+
+	// ConfigSchema is the JSON Schema of the config the plugin stores under its key in the project config, if any
+	ConfigSchema *config.Schema `json:"configSchema,omitempty"`
+}
+
+// SubcommandMetadata describes a command implemented by an external plugin
+type SubcommandMetadata struct {
+	// Description is the help text of the command
+	Description string `json:"description,omitempty"`
+
+	// Examples are one or more usage examples of the command
+	Examples string `json:"examples,omitempty"`
+
+	// Flags are the flags the command accepts
+	Flags []Flag `json:"flags,omitempty"`
+}
+
+// Flag types supported by the protocol
+const (
+	StringFlag = "string"
+	BoolFlag   = "bool"
+)
+
+// Flag describes a command line flag of an external plugin command
+type Flag struct {
+	// Name is the flag name without leading dashes
+	Name string `json:"name"`
+
+	// Type is either StringFlag or BoolFlag, defaults to StringFlag
+	Type string `json:"type,omitempty"`
+
+	// Default is the default value of the flag
+	Default string `json:"default,omitempty"`
+
+	// Usage is the help text of the flag
+	Usage string `json:"usage,omitempty"`
+}
@@ -17,9 +17,13 @@ limitations under the License.
 package plugin
 
 import (
+	"io"
+
+	"github.com/spf13/afero"
 	"github.com/spf13/pflag"
 
 	"sigs.k8s.io/kubebuilder/pkg/model/config"
+	"sigs.k8s.io/kubebuilder/pkg/model/file"
 )
 
 // Base is an interface that defines the common base for all plugins
@@ -68,12 +72,54 @@ type Context struct {
 	// Examples are one or more examples of the command-line usage
 	// of this plugin's project subcommand support. It is used to display help.
 	Examples string
+
+	// Filesystem is the file system scaffolded files are read from and written to.
+	// If nil, the OS file system is used.
+	Filesystem afero.Fs
+	// Report collects the operations performed on scaffolded files. It may be nil.
+	Report *file.Report
+	// DryRun is set when the subcommand must not have side effects other than writing to Filesystem,
+	// e.g. post-scaffolding commands like `go mod tidy` or `make` are not run.
+	DryRun bool
+	// Prompter asks the user the questions that were not answered through flags.
+	Prompter Prompter
+
+	// Stdout receives the messages printed by the plugin and the output of the commands it runs.
+	// It must be set.
+	Stdout io.Writer
+	// Stderr receives the errors printed by the commands the plugin runs.
+	Stderr io.Writer
+	// WorkingDirectory is the absolute path of the project root. Filesystem paths are relative
+	// to it and the commands run by the plugin must run in it.
+	WorkingDirectory string
+	// Templates is the file system the overrides of the template bodies are read from, the project
+	// template directory by default. It may be nil.
+	Templates afero.Fs
+}
+
+// Prompter asks the user yes/no questions. Plugins must use it instead of reading
+// from stdin so that the CLI can run non-interactively.
+type Prompter interface {
+	// Confirm answers every question, setting its Answer. An error is returned if a question
+	// cannot be answered, e.g. prompts are disabled or stdin was closed, naming the flags
+	// that should be used instead.
+	Confirm(questions ...Question) error
+}
+
+// Question is a yes/no question that can also be answered by setting a flag.
+type Question struct {
+	// Message is the question shown to the user.
+	Message string
+	// Flag is the name of the flag that answers the question.
+	Flag string
+	// Answer is set to the answer, its initial value is used as default.
+	Answer *bool
 }
 
 // InitPluginGetter is an interface that defines gets an Init plugin
 type InitPluginGetter interface {
 	Base
-	// GetInitPlugin returns the underlying Init interface.
+	// GetInitPlugin returns the underlying Init interface, or nil if it is not implemented.
 	GetInitPlugin() Init
 }
 
@@ -85,7 +131,7 @@ type Init interface {
 // CreateAPIPluginGetter is an interface that defines gets an Create API plugin
 type CreateAPIPluginGetter interface {
 	Base
-	// GetCreateAPIPlugin returns the underlying CreateAPI interface.
+	// GetCreateAPIPlugin returns the underlying CreateAPI interface, or nil if it is not implemented.
 	GetCreateAPIPlugin() CreateAPI
 }
 
@@ -97,7 +143,7 @@ type CreateAPI interface {
 // CreateWebhookPluginGetter is an interface that defines gets an Create WebHook plugin
 type CreateWebhookPluginGetter interface {
 	Base
-	// GetCreateWebhookPlugin returns the underlying CreateWebhook interface.
+	// GetCreateWebhookPlugin returns the underlying CreateWebhook interface, or nil if it is not implemented.
 	GetCreateWebhookPlugin() CreateWebhook
 }
 
@@ -105,3 +151,72 @@ type CreateWebhookPluginGetter interface {
 type CreateWebhook interface {
 	GenericSubcommand
 }
+
+// EditPluginGetter is an interface that defines gets an Edit plugin
+type EditPluginGetter interface {
+	Base
+	// GetEditPlugin returns the underlying Edit interface, or nil if it is not implemented.
+	GetEditPlugin() Edit
+}
+
+// Edit is an interface that represents an `edit` command
+type Edit interface {
+	GenericSubcommand
+}
+
+// DeleteAPIPluginGetter is an interface that defines gets a Delete API plugin
+type DeleteAPIPluginGetter interface {
+	Base
+	// GetDeleteAPIPlugin returns the underlying DeleteAPI interface, or nil if it is not implemented.
+	GetDeleteAPIPlugin() DeleteAPI
+}
+
+// DeleteAPI is an interface that represents a `delete api` command
+type DeleteAPI interface {
+	GenericSubcommand
+}
+
+// DeleteWebhookPluginGetter is an interface that defines gets a Delete Webhook plugin
+type DeleteWebhookPluginGetter interface {
+	Base
+	// GetDeleteWebhookPlugin returns the underlying DeleteWebhook interface, or nil if it is not implemented.
+	GetDeleteWebhookPlugin() DeleteWebhook
+}
+
+// DeleteWebhook is an interface that represents a `delete webhook` command
+type DeleteWebhook interface {
+	GenericSubcommand
+}
+
+// ConfigSchemaGetter is an interface that defines gets the schema of a plugin config
+type ConfigSchemaGetter interface {
+	Base
+	// GetConfigSchema returns the schema of the config the plugin stores under its key in the `plugins` field
+	// of the project config: either a *config.Schema or a value of the struct the config is decoded into.
+	// It returns nil if the plugin stores no config.
+	GetConfigSchema() interface{}
+}
+
+// ExtraCommandsGetter is an interface that defines gets the extra commands of a plugin
+type ExtraCommandsGetter interface {
+	Base
+	// GetExtraCommands returns the commands contributed by the plugin. They are only
+	// added to the CLI when the plugin is part of the project layout.
+	GetExtraCommands() []ExtraCommand
+}
+
+// ExtraCommand describes a command contributed by a plugin, added either to the root
+// command or to the `alpha` command group
+type ExtraCommand struct {
+	// Name is the name used to invoke the command, ex. "generate-bundle".
+	Name string
+	// Short is the short description shown in the parent command help.
+	Short string
+	// Alpha adds the command to the `alpha` command group instead of the root command.
+	Alpha bool
+	// ProjectVersions are the versions of the projects the command is also added to when the plugin is not
+	// part of their layout, ex. the project versions a command migrates projects from.
+	ProjectVersions []string
+	// Subcommand implements the command.
+	Subcommand GenericSubcommand
+}
@@ -109,6 +109,27 @@ func IsCreateFileError(err error) bool {
 	return errors.As(err, &createFileError{})
 }
 
+// removeFileError is returned if the file could not be removed
+type removeFileError struct {
+	path string
+	err  error
+}
+
+// Error implements error interface
+func (e removeFileError) Error() string {
+	return fmt.Sprintf("failed to remove %s: %v", e.path, e.err)
+}
+
+// Unwrap implements Wrapper interface
+func (e removeFileError) Unwrap() error {
+	return e.err
+}
+
+// IsRemoveFileError checks if the returned error is because the file could not be removed
+func IsRemoveFileError(err error) bool {
+	return errors.As(err, &removeFileError{})
+}
+
 // readFileError is returned if the file could not be read
 type readFileError struct {
 	path string
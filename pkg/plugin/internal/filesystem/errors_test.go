@@ -39,6 +39,7 @@ var _ = Describe("Errors", func() {
 		openFileErr        = openFileError{path, err}
 		createDirectoryErr = createDirectoryError{path, err}
 		createFileErr      = createFileError{path, err}
+		removeFileErr      = removeFileError{path, err}
 		readFileErr        = readFileError{path, err}
 		writeFileErr       = writeFileError{path, err}
 		closeFileErr       = closeFileError{path, err}
@@ -52,19 +53,21 @@ var _ = Describe("Errors", func() {
 			}
 		},
 		Entry("file exists", IsFileExistsError, fileExistsErr,
-			openFileErr, createDirectoryErr, createFileErr, readFileErr, writeFileErr, closeFileErr),
+			openFileErr, createDirectoryErr, createFileErr, removeFileErr, readFileErr, writeFileErr, closeFileErr),
 		Entry("open file", IsOpenFileError, openFileErr,
-			fileExistsErr, createDirectoryErr, createFileErr, readFileErr, writeFileErr, closeFileErr),
+			fileExistsErr, createDirectoryErr, createFileErr, removeFileErr, readFileErr, writeFileErr, closeFileErr),
 		Entry("create directory", IsCreateDirectoryError, createDirectoryErr,
-			fileExistsErr, openFileErr, createFileErr, readFileErr, writeFileErr, closeFileErr),
+			fileExistsErr, openFileErr, createFileErr, removeFileErr, readFileErr, writeFileErr, closeFileErr),
 		Entry("create file", IsCreateFileError, createFileErr,
-			fileExistsErr, openFileErr, createDirectoryErr, readFileErr, writeFileErr, closeFileErr),
+			fileExistsErr, openFileErr, createDirectoryErr, removeFileErr, readFileErr, writeFileErr, closeFileErr),
+		Entry("remove file", IsRemoveFileError, removeFileErr,
+			fileExistsErr, openFileErr, createDirectoryErr, createFileErr, readFileErr, writeFileErr, closeFileErr),
 		Entry("read file", IsReadFileError, readFileErr,
-			fileExistsErr, openFileErr, createDirectoryErr, createFileErr, writeFileErr, closeFileErr),
+			fileExistsErr, openFileErr, createDirectoryErr, createFileErr, removeFileErr, writeFileErr, closeFileErr),
 		Entry("write file", IsWriteFileError, writeFileErr,
-			fileExistsErr, openFileErr, createDirectoryErr, createFileErr, readFileErr, closeFileErr),
+			fileExistsErr, openFileErr, createDirectoryErr, createFileErr, removeFileErr, readFileErr, closeFileErr),
 		Entry("close file", IsCloseFileError, closeFileErr,
-			fileExistsErr, openFileErr, createDirectoryErr, createFileErr, readFileErr, writeFileErr),
+			fileExistsErr, openFileErr, createDirectoryErr, createFileErr, removeFileErr, readFileErr, writeFileErr),
 	)
 
 	DescribeTable("should contain the wrapped error and error message",
@@ -76,6 +79,7 @@ var _ = Describe("Errors", func() {
 		Entry("open file", openFileErr),
 		Entry("create directory", createDirectoryErr),
 		Entry("create file", createFileErr),
+		Entry("remove file", removeFileErr),
 		Entry("read file", readFileErr),
 		Entry("write file", writeFileErr),
 		Entry("close file", closeFileErr),
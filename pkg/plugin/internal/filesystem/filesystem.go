@@ -42,6 +42,9 @@ type FileSystem interface {
 	// Create creates the directory and file and returns a self-closing
 	// io.Writer pointing to that file. If the file exists, it truncates it.
 	Create(path string) (io.Writer, error)
+
+	// Remove removes the file
+	Remove(path string) error
 }
 
 // fileSystem implements FileSystem
@@ -88,6 +91,14 @@ func FilePermissions(filePerm os.FileMode) Options {
 	}
 }
 
+// AferoFs makes FileSystem read from and write to the provided afero.Fs
+// instead of the OS file system
+func AferoFs(aferoFs afero.Fs) Options {
+	return func(fs *fileSystem) {
+		fs.fs = aferoFs
+	}
+}
+
 // Exists implements FileSystem.Exists
 func (fs fileSystem) Exists(path string) (bool, error) {
 	exists, err := afero.Exists(fs.fs, path)
@@ -124,6 +135,15 @@ func (fs fileSystem) Create(path string) (io.Writer, error) {
 	return &writeFile{path, wc}, nil
 }
 
+// Remove implements FileSystem.Remove
+func (fs fileSystem) Remove(path string) error {
+	if err := fs.fs.Remove(path); err != nil {
+		return removeFileError{path, err}
+	}
+
+	return nil
+}
+
 var _ io.ReadCloser = &readFile{}
 
 // readFile implements io.Reader
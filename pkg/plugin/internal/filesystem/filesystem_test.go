@@ -22,6 +22,8 @@ import (
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+
+	"github.com/spf13/afero"
 )
 
 func TestFileSystem(t *testing.T) {
@@ -149,6 +151,24 @@ var _ = Describe("FileSystem", func() {
 				Expect(fs.fileMode).To(Equal(createOrUpdate))
 			})
 		})
+
+		Context("when using an afero.Fs option", func() {
+			var aferoFs afero.Fs
+
+			BeforeEach(func() {
+				aferoFs = afero.NewMemMapFs()
+				fsi = New(AferoFs(aferoFs))
+				fs, ok = fsi.(fileSystem)
+			})
+
+			It("should be a fileSystem instance", func() {
+				Expect(ok).To(BeTrue())
+			})
+
+			It("should use the provided fs", func() {
+				Expect(fs.fs).To(BeIdenticalTo(aferoFs))
+			})
+		})
 	})
 
 	// NOTE: FileSystem.Exists, FileSystem.Open, FileSystem.Open().Read, FileSystem.Create and FileSystem.Create().Write
@@ -29,6 +29,7 @@ type mockFileSystem struct {
 	openFileError   error
 	createDirError  error
 	createFileError error
+	removeFileError error
 	input           *bytes.Buffer
 	readFileError   error
 	output          *bytes.Buffer
@@ -97,6 +98,13 @@ func MockCreateFileError(err error) MockOptions {
 	}
 }
 
+// MockRemoveFileError makes FileSystem.Remove return err
+func MockRemoveFileError(err error) MockOptions {
+	return func(fs *mockFileSystem) {
+		fs.removeFileError = err
+	}
+}
+
 // MockInput provides a buffer where the content will be read from
 func MockInput(input *bytes.Buffer) MockOptions {
 	return func(fs *mockFileSystem) {
@@ -167,6 +175,15 @@ func (fs mockFileSystem) Create(path string) (io.Writer, error) {
 	return &mockWriteFile{path, fs.output, fs.writeFileError, fs.closeFileError}, nil
 }
 
+// Remove implements FileSystem.Remove
+func (fs mockFileSystem) Remove(path string) error {
+	if fs.removeFileError != nil {
+		return removeFileError{path, fs.removeFileError}
+	}
+
+	return nil
+}
+
 // mockReadFile implements io.Reader mocking a readFile for tests
 type mockReadFile struct {
 	path           string
@@ -0,0 +1,166 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machinery
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// diffContext is the number of unchanged lines shown around the changes of a diff
+	diffContext = 3
+
+	// maxDiffCells bounds the size of the table used to diff two files, larger files are diffed as a whole
+	maxDiffCells = 4000000
+)
+
+// edit is a line of a diff, kind is ' ' for unchanged lines, '-' for removed lines and '+' for added lines
+type edit struct {
+	kind byte
+	line string
+}
+
+// unifiedDiff returns the unified diff from the current contents of the file at path to the scaffolded ones
+func unifiedDiff(path, current, scaffolded string) string {
+	edits := diffLines(splitLines(current), splitLines(scaffolded))
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "--- %s\n+++ %s (scaffolded)\n", path, path)
+	for _, h := range hunks(edits) {
+		writeHunk(&sb, edits, h[0], h[1])
+	}
+	return sb.String()
+}
+
+// splitLines splits s into lines without their line endings
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+}
+
+// diffLines returns the edits that turn a into b, based on their longest common subsequence
+func diffLines(a, b []string) []edit {
+	edits := make([]edit, 0, len(a)+len(b))
+	if len(a)*len(b) > maxDiffCells {
+		for _, line := range a {
+			edits = append(edits, edit{'-', line})
+		}
+		for _, line := range b {
+			edits = append(edits, edit{'+', line})
+		}
+		return edits
+	}
+
+	// lcs[i][j] is the length of the longest common subsequence of a[i:] and b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			edits = append(edits, edit{' ', a[i]})
+			i++
+			j++
+		case j == len(b) || i < len(a) && lcs[i+1][j] >= lcs[i][j+1]:
+			edits = append(edits, edit{'-', a[i]})
+			i++
+		default:
+			edits = append(edits, edit{'+', b[j]})
+			j++
+		}
+	}
+	return edits
+}
+
+// hunks returns the [start, end) ranges of edits shown in a diff, which are the changes and their context
+func hunks(edits []edit) [][2]int {
+	var ranges [][2]int
+	for i, e := range edits {
+		if e.kind == ' ' {
+			continue
+		}
+
+		start, end := i-diffContext, i+1+diffContext
+		if start < 0 {
+			start = 0
+		}
+		if end > len(edits) {
+			end = len(edits)
+		}
+		// Merge with the previous hunk if their contexts overlap or touch
+		if n := len(ranges); n != 0 && start <= ranges[n-1][1] {
+			ranges[n-1][1] = end
+			continue
+		}
+		ranges = append(ranges, [2]int{start, end})
+	}
+	return ranges
+}
+
+// writeHunk writes the edits in [start, end) as a hunk, with their line ranges in the current and scaffolded files
+func writeHunk(sb *strings.Builder, edits []edit, start, end int) {
+	// Line numbers start at 1
+	currentStart, scaffoldedStart := 1, 1
+	for _, e := range edits[:start] {
+		if e.kind != '+' {
+			currentStart++
+		}
+		if e.kind != '-' {
+			scaffoldedStart++
+		}
+	}
+	currentLen, scaffoldedLen := 0, 0
+	for _, e := range edits[start:end] {
+		if e.kind != '+' {
+			currentLen++
+		}
+		if e.kind != '-' {
+			scaffoldedLen++
+		}
+	}
+	// Empty ranges refer to the line before them
+	if currentLen == 0 {
+		currentStart--
+	}
+	if scaffoldedLen == 0 {
+		scaffoldedStart--
+	}
+
+	fmt.Fprintf(sb, "@@ -%d,%d +%d,%d @@\n", currentStart, currentLen, scaffoldedStart, scaffoldedLen)
+	for _, e := range edits[start:end] {
+		sb.WriteByte(e.kind)
+		sb.WriteString(e.line)
+		sb.WriteByte('\n')
+	}
+}
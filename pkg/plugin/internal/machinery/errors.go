@@ -19,6 +19,7 @@ package machinery
 import (
 	"errors"
 	"fmt"
+	"os"
 
 	"sigs.k8s.io/kubebuilder/pkg/model/file"
 )
@@ -37,6 +38,11 @@ func (e fileAlreadyExistsError) Error() string {
 	return fmt.Sprintf("failed to create %s: file already exists", e.path)
 }
 
+// Unwrap implements Wrapper interface, so that errors.Is(err, os.ErrExist) holds
+func (e fileAlreadyExistsError) Unwrap() error {
+	return os.ErrExist
+}
+
 // IsFileAlreadyExistsError checks if the returned error is because the file already existed when expected not to
 func IsFileAlreadyExistsError(err error) bool {
 	return errors.As(err, &fileAlreadyExistsError{})
@@ -72,3 +78,39 @@ func (e unknownIfExistsActionError) Error() string {
 func IsUnknownIfExistsActionError(err error) bool {
 	return errors.As(err, &unknownIfExistsActionError{})
 }
+
+// insertionPointNotFoundError is returned if the point where code fragments have to be inserted is not found
+type insertionPointNotFoundError struct {
+	path   string
+	marker file.Marker
+	reason string
+}
+
+// Error implements error interface
+func (e insertionPointNotFoundError) Error() string {
+	return fmt.Sprintf("unable to find where to insert code into %s: %s, add the marker \"%s\" where the code "+
+		"has to be inserted", e.path, e.reason, e.marker)
+}
+
+// IsInsertionPointNotFoundError checks if the returned error is because the point where code fragments have to
+// be inserted was not found
+func IsInsertionPointNotFoundError(err error) bool {
+	return errors.As(err, &insertionPointNotFoundError{})
+}
+
+// notAListError is returned if a YAML entry has to be inserted into a top-level key whose value is not a block list
+type notAListError struct {
+	path string
+	key  string
+}
+
+// Error implements error interface
+func (e notAListError) Error() string {
+	return fmt.Sprintf("unable to insert into %s: the value of %q is not a block list", e.path, e.key)
+}
+
+// IsNotAListError checks if the returned error is because a YAML entry had to be inserted into a value which is
+// not a block list
+func IsNotAListError(err error) bool {
+	return errors.As(err, &notAListError{})
+}
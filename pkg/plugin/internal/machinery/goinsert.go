@@ -0,0 +1,203 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machinery
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"sort"
+	"strings"
+
+	"sigs.k8s.io/kubebuilder/pkg/model/file"
+)
+
+// insertion is a text inserted at an offset of a file
+type insertion struct {
+	offset int
+	text   string
+}
+
+// insertGoStrings inserts the code fragments into the Go content at the insertion points located by analyzing
+// the code. The code fragments of the markers without insertion point, or of a file that cannot be parsed, are
+// inserted at their markers. It fails if any of the insertion points is not found.
+func insertGoStrings(
+	path, content string,
+	codeFragmentsMap file.CodeFragmentsMap,
+	points map[file.Marker]file.InsertionPoint,
+) ([]byte, error) {
+	fset := token.NewFileSet()
+	f, parseErr := parser.ParseFile(fset, path, content, parser.ParseComments)
+	l := goLocator{fset: fset, file: f, content: content}
+
+	markers := make([]file.Marker, 0, len(codeFragmentsMap))
+	for marker := range codeFragmentsMap {
+		markers = append(markers, marker)
+	}
+	sort.Slice(markers, func(i, j int) bool { return markers[i].String() < markers[j].String() })
+
+	insertions := make([]insertion, 0, len(markers))
+	for _, marker := range markers {
+		ins := insertion{offset: -1, text: strings.Join(codeFragmentsMap[marker], "")}
+
+		point, hasPoint := points[marker]
+		var reason string
+		switch {
+		case hasPoint && parseErr == nil:
+			ins, reason = l.locate(marker, point, ins.text)
+		case hasPoint:
+			if ins.offset = findMarker(content, 0, len(content), marker); ins.offset == -1 {
+				reason = "the file could not be parsed (" + parseErr.Error() + ") and the marker was not found"
+			}
+		default:
+			if ins.offset = findMarker(content, 0, len(content), marker); ins.offset == -1 {
+				reason = "the marker was not found"
+			}
+		}
+		if reason != "" {
+			return nil, insertionPointNotFoundError{path, marker, reason}
+		}
+		insertions = append(insertions, ins)
+	}
+
+	// Insert in order, the insertions at the same offset keep the order of their markers
+	sort.SliceStable(insertions, func(i, j int) bool { return insertions[i].offset < insertions[j].offset })
+	var sb strings.Builder
+	last := 0
+	for _, ins := range insertions {
+		sb.WriteString(content[last:ins.offset])
+		sb.WriteString(ins.text)
+		last = ins.offset
+	}
+	sb.WriteString(content[last:])
+	return []byte(sb.String()), nil
+}
+
+// goLocator locates insertion points in a parsed Go file
+type goLocator struct {
+	fset    *token.FileSet
+	file    *ast.File
+	content string
+}
+
+// locate returns the insertion of text at point, or the reason why point was not found.
+// If the marker is found where the text would be inserted, the text is inserted at the marker instead.
+func (l goLocator) locate(marker file.Marker, point file.InsertionPoint, text string) (insertion, string) {
+	if point.Imports {
+		return l.locateImports(marker, text), ""
+	}
+
+	body := l.findBody(point.Func)
+	if body == nil {
+		return insertion{}, "function " + point.Func + " was not found"
+	}
+	if offset := findMarker(l.content, l.offset(body.Lbrace), l.offset(body.Rbrace), marker); offset != -1 {
+		return insertion{offset, text}, ""
+	}
+
+	if point.Before == "" {
+		return l.before(l.offset(body.Rbrace), text), ""
+	}
+	before := removeWhitespace(point.Before)
+	for _, stmt := range body.List {
+		start := l.offset(stmt.Pos())
+		if strings.HasPrefix(removeWhitespace(l.content[start:l.offset(stmt.End())]), before) {
+			return l.before(start, text), ""
+		}
+	}
+	return insertion{}, "function " + point.Func + " has no statement starting with " + point.Before
+}
+
+// locateImports returns the insertion of text into the first grouped import declaration,
+// or of a new import declaration after the package clause if there is none
+func (l goLocator) locateImports(marker file.Marker, text string) insertion {
+	for _, decl := range l.file.Decls {
+		gen, isGenDecl := decl.(*ast.GenDecl)
+		if !isGenDecl || gen.Tok != token.IMPORT || !gen.Lparen.IsValid() {
+			continue
+		}
+
+		if offset := findMarker(l.content, l.offset(gen.Lparen), l.offset(gen.Rparen), marker); offset != -1 {
+			return insertion{offset, text}
+		}
+		return l.before(l.offset(gen.Rparen), text)
+	}
+
+	return insertion{l.offset(l.file.Name.End()), "\n\nimport (\n" + text + ")"}
+}
+
+// findBody returns the body of the function called name, or of the first function literal passed to a call
+// of the function called name, or nil if none is found
+func (l goLocator) findBody(name string) *ast.BlockStmt {
+	for _, decl := range l.file.Decls {
+		if fn, isFunc := decl.(*ast.FuncDecl); isFunc && fn.Recv == nil && fn.Name.Name == name && fn.Body != nil {
+			return fn.Body
+		}
+	}
+
+	var body *ast.BlockStmt
+	ast.Inspect(l.file, func(n ast.Node) bool {
+		if body != nil {
+			return false
+		}
+		call, isCall := n.(*ast.CallExpr)
+		if !isCall {
+			return true
+		}
+		if ident, isIdent := call.Fun.(*ast.Ident); isIdent && ident.Name == name {
+			for _, arg := range call.Args {
+				if lit, isFuncLit := arg.(*ast.FuncLit); isFuncLit {
+					body = lit.Body
+					return false
+				}
+			}
+		}
+		return true
+	})
+	return body
+}
+
+// before returns the insertion of text before the token at offset, in lines of their own
+func (l goLocator) before(offset int, text string) insertion {
+	lineStart := strings.LastIndex(l.content[:offset], "\n") + 1
+	if strings.TrimSpace(l.content[lineStart:offset]) == "" {
+		return insertion{lineStart, text}
+	}
+	return insertion{offset, "\n" + text}
+}
+
+// offset returns the offset of pos in the content
+func (l goLocator) offset(pos token.Pos) int {
+	return l.fset.Position(pos).Offset
+}
+
+// findMarker returns the offset of the first line of content between from and to that is the marker, or -1
+func findMarker(content string, from, to int, marker file.Marker) int {
+	offset := strings.LastIndex(content[:from], "\n") + 1
+	for _, line := range strings.SplitAfter(content[offset:to], "\n") {
+		if strings.TrimSpace(line) == strings.TrimSpace(marker.String()) {
+			return offset
+		}
+		offset += len(line)
+	}
+	return -1
+}
+
+// removeWhitespace returns s without whitespace, so that code is compared regardless of its formatting
+func removeWhitespace(s string) string {
+	return strings.Join(strings.Fields(s), "")
+}
@@ -0,0 +1,175 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machinery
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	"sigs.k8s.io/kubebuilder/pkg/model/file"
+)
+
+var _ = Describe("insertGoStrings", func() {
+	var (
+		importMarker = file.NewMarkerFor("main.go", "imports")
+		initMarker   = file.NewMarkerFor("main.go", "init")
+		mainMarker   = file.NewMarkerFor("main.go", "main")
+		suiteMarker  = file.NewMarkerFor("main.go", "suite")
+
+		points = map[file.Marker]file.InsertionPoint{
+			importMarker: {Imports: true},
+			initMarker:   {Func: "init"},
+			mainMarker:   {Func: "main", Before: `fmt.Println("end")`},
+			suiteMarker:  {Func: "BeforeSuite"},
+		}
+	)
+
+	DescribeTable("should insert the code fragments",
+		func(content, expected string, codeFragments file.CodeFragmentsMap) {
+			b, err := insertGoStrings("main.go", content, codeFragments, points)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(b)).To(Equal(expected))
+		},
+		Entry("at the insertion points found in the code",
+			`package main
+
+import (
+	"fmt"
+)
+
+func init() {
+	fmt.Println("init")
+}
+
+func main() {
+	fmt.Println("start")
+	fmt.Println( "end" )
+}
+`,
+			`package main
+
+import (
+	"fmt"
+"os"
+)
+
+func init() {
+	fmt.Println("init")
+os.Exit(0)
+}
+
+func main() {
+	fmt.Println("start")
+os.Exit(1)
+	fmt.Println( "end" )
+}
+`,
+			file.CodeFragmentsMap{
+				importMarker: {"\"os\"\n"},
+				initMarker:   {"os.Exit(0)\n"},
+				mainMarker:   {"os.Exit(1)\n"},
+			},
+		),
+		Entry("at the markers found where the code fragments would be inserted",
+			`package main
+
+import (
+	// +kubebuilder:scaffold:imports
+	"fmt"
+)
+
+func init() {
+	// +kubebuilder:scaffold:init
+	fmt.Println("init")
+}
+`,
+			`package main
+
+import (
+"os"
+	// +kubebuilder:scaffold:imports
+	"fmt"
+)
+
+func init() {
+os.Exit(0)
+	// +kubebuilder:scaffold:init
+	fmt.Println("init")
+}
+`,
+			file.CodeFragmentsMap{
+				importMarker: {"\"os\"\n"},
+				initMarker:   {"os.Exit(0)\n"},
+			},
+		),
+		Entry("into a function literal passed to the function",
+			`package main
+
+var _ = BeforeSuite(func() {
+	setup() })
+`,
+			`package main
+
+var _ = BeforeSuite(func() {
+	setup() 
+os.Exit(0)
+})
+`,
+			file.CodeFragmentsMap{suiteMarker: {"os.Exit(0)\n"}},
+		),
+		Entry("into a new import declaration",
+			"package main\n",
+			"package main\n\nimport (\n\"os\"\n)\n",
+			file.CodeFragmentsMap{importMarker: {"\"os\"\n"}},
+		),
+		Entry("at the markers of a file that cannot be parsed",
+			"package main\n\nfunc init() {\n// +kubebuilder:scaffold:init\n",
+			"package main\n\nfunc init() {\nos.Exit(0)\n// +kubebuilder:scaffold:init\n",
+			file.CodeFragmentsMap{initMarker: {"os.Exit(0)\n"}},
+		),
+	)
+
+	DescribeTable("should fail if the insertion point is not found",
+		func(content, reason string, codeFragments file.CodeFragmentsMap) {
+			_, err := insertGoStrings("main.go", content, codeFragments, points)
+			Expect(err).To(HaveOccurred())
+			Expect(IsInsertionPointNotFoundError(err)).To(BeTrue())
+			Expect(err.Error()).To(ContainSubstring(reason))
+		},
+		Entry("for a missing function",
+			"package main\n",
+			"function init was not found",
+			file.CodeFragmentsMap{initMarker: {"os.Exit(0)\n"}},
+		),
+		Entry("for a missing statement",
+			"package main\n\nfunc main() {}\n",
+			`function main has no statement starting with fmt.Println("end")`,
+			file.CodeFragmentsMap{mainMarker: {"os.Exit(0)\n"}},
+		),
+		Entry("for a missing marker without insertion point",
+			"package main\n",
+			"the marker was not found",
+			file.CodeFragmentsMap{file.NewMarkerFor("main.go", "other"): {"os.Exit(0)\n"}},
+		),
+		Entry("for a file that cannot be parsed without the marker",
+			"package main\n\nfunc init() {\n",
+			"the file could not be parsed",
+			file.CodeFragmentsMap{initMarker: {"os.Exit(0)\n"}},
+		),
+	)
+})
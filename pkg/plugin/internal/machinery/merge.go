@@ -0,0 +1,132 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machinery
+
+import (
+	"strings"
+)
+
+const (
+	// conflictStart, conflictSeparator and conflictEnd delimit the current and the scaffolded lines of a conflict
+	conflictStart     = "<<<<<<< current"
+	conflictSeparator = "======="
+	conflictEnd       = ">>>>>>> scaffolded"
+)
+
+// Merge merges the changes from base to scaffolded into current, which are the changes made to the scaffold since
+// the base was rendered, and returns the merged contents and the number of conflicts.
+// Conflicts are chunks changed in both current and scaffolded, and are delimited by conflict markers.
+func Merge(base, current, scaffolded string) (string, int) {
+	return merge(splitLines(base), splitLines(current), splitLines(scaffolded))
+}
+
+// MergeWithoutBase merges scaffolded into current when the base they come from is unknown, and returns the
+// merged contents and the number of conflicts.
+// Their common lines are used as base, so lines only added by one of them are kept and lines that differ
+// conflict.
+func MergeWithoutBase(current, scaffolded string) (string, int) {
+	currentLines, scaffoldedLines := splitLines(current), splitLines(scaffolded)
+	var base []string
+	for _, e := range diffLines(currentLines, scaffoldedLines) {
+		if e.kind == ' ' {
+			base = append(base, e.line)
+		}
+	}
+	return merge(base, currentLines, scaffoldedLines)
+}
+
+// merge is a three-way merge of the lines of current and scaffolded, which are both derived from base
+func merge(base, current, scaffolded []string) (string, int) {
+	currentMatches, scaffoldedMatches := matches(base, current), matches(base, scaffolded)
+
+	var (
+		lines     []string
+		conflicts int
+	)
+	b, c, s := 0, 0, 0
+	for {
+		// The next stable line is the next base line kept in both current and scaffolded
+		next := b
+		for next < len(base) && (currentMatches[next] == -1 || scaffoldedMatches[next] == -1) {
+			next++
+		}
+		nextCurrent, nextScaffolded := len(current), len(scaffolded)
+		if next < len(base) {
+			nextCurrent, nextScaffolded = currentMatches[next], scaffoldedMatches[next]
+		}
+
+		// Resolve the unstable chunk before the stable line
+		baseChunk, currentChunk, scaffoldedChunk := base[b:next], current[c:nextCurrent], scaffolded[s:nextScaffolded]
+		switch {
+		case equalLines(baseChunk, currentChunk):
+			lines = append(lines, scaffoldedChunk...)
+		case equalLines(baseChunk, scaffoldedChunk), equalLines(currentChunk, scaffoldedChunk):
+			lines = append(lines, currentChunk...)
+		default:
+			lines = append(lines, conflictStart)
+			lines = append(lines, currentChunk...)
+			lines = append(lines, conflictSeparator)
+			lines = append(lines, scaffoldedChunk...)
+			lines = append(lines, conflictEnd)
+			conflicts++
+		}
+
+		if next == len(base) {
+			break
+		}
+		lines = append(lines, base[next])
+		b, c, s = next+1, nextCurrent+1, nextScaffolded+1
+	}
+
+	if len(lines) == 0 {
+		return "", conflicts
+	}
+	return strings.Join(lines, "\n") + "\n", conflicts
+}
+
+// matches returns, for each line of base, the line of derived it was kept as, or -1 if it was removed
+func matches(base, derived []string) []int {
+	m := make([]int, len(base))
+	i, j := 0, 0
+	for _, e := range diffLines(base, derived) {
+		switch e.kind {
+		case ' ':
+			m[i] = j
+			i++
+			j++
+		case '-':
+			m[i] = -1
+			i++
+		default:
+			j++
+		}
+	}
+	return m
+}
+
+// equalLines returns true if a and b have the same lines
+func equalLines(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
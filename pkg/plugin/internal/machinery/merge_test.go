@@ -0,0 +1,53 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machinery
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Merge", func() {
+	DescribeTable("should merge the scaffold changes into the current contents",
+		func(base, current, scaffolded, expected string, expectedConflicts int) {
+			merged, conflicts := Merge(base, current, scaffolded)
+			Expect(merged).To(Equal(expected))
+			Expect(conflicts).To(Equal(expectedConflicts))
+		},
+		Entry("when only the scaffold changed", "a\nb\nc\n", "a\nb\nc\n", "a\nB\nc\n", "a\nB\nc\n", 0),
+		Entry("when only the current contents changed", "a\nb\nc\n", "a\nb\nc\nd\n", "a\nb\nc\n", "a\nb\nc\nd\n", 0),
+		Entry("when both changed different lines",
+			"a\nb\nc\nd\ne\n", "A\nb\nc\nd\ne\n", "a\nb\nc\nd\nE\n", "A\nb\nc\nd\nE\n", 0),
+		Entry("when both made the same change", "a\nb\n", "a\nB\n", "a\nB\n", "a\nB\n", 0),
+		Entry("when both removed different lines", "a\nb\nc\nd\n", "b\nc\nd\n", "a\nb\nc\n", "b\nc\n", 0),
+		Entry("with conflict markers when both changed the same lines",
+			"a\nb\nc\n", "a\nmine\nc\n", "a\ntheirs\nc\n",
+			"a\n<<<<<<< current\nmine\n=======\ntheirs\n>>>>>>> scaffolded\nc\n", 1),
+	)
+
+	DescribeTable("should merge without base",
+		func(current, scaffolded, expected string, expectedConflicts int) {
+			merged, conflicts := MergeWithoutBase(current, scaffolded)
+			Expect(merged).To(Equal(expected))
+			Expect(conflicts).To(Equal(expectedConflicts))
+		},
+		Entry("keeping the lines added by any of them", "a\nmine\nb\n", "a\nb\ntheirs\n", "a\nmine\nb\ntheirs\n", 0),
+		Entry("with conflict markers when lines differ", "a\nmine\nb\n", "a\ntheirs\nb\n",
+			"a\n<<<<<<< current\nmine\n=======\ntheirs\n>>>>>>> scaffolded\nb\n", 1),
+	)
+})
@@ -21,10 +21,15 @@ import (
 	"bytes"
 	"fmt"
 	"io/ioutil"
+	"os"
 	"path/filepath"
+	"runtime"
+	"sort"
 	"strings"
+	"sync"
 	"text/template"
 
+	"github.com/spf13/afero"
 	"golang.org/x/tools/imports"
 
 	"sigs.k8s.io/kubebuilder/pkg/model"
@@ -39,10 +44,48 @@ var options = imports.Options{
 	FormatOnly: true,
 }
 
+var (
+	// importsMu guards imports.LocalPrefix, a package-level setting of the imports package,
+	// so that projects with different repositories can be scaffolded concurrently.
+	importsMu sync.Mutex
+	// importsDone is signaled when no file is being processed anymore, so that imports.LocalPrefix can change
+	importsDone = sync.NewCond(&importsMu)
+	// importsUsers is the number of files being processed with the current imports.LocalPrefix
+	importsUsers int
+)
+
+// processImports formats the Go file at path grouping the imports with the localPrefix apart.
+// Files with the same localPrefix are processed concurrently.
+func processImports(path string, src []byte, opt *imports.Options, localPrefix string) ([]byte, error) {
+	importsMu.Lock()
+	for importsUsers != 0 && imports.LocalPrefix != localPrefix {
+		importsDone.Wait()
+	}
+	if imports.LocalPrefix != localPrefix {
+		imports.LocalPrefix = localPrefix
+	}
+	importsUsers++
+	importsMu.Unlock()
+
+	defer func() {
+		importsMu.Lock()
+		if importsUsers--; importsUsers == 0 {
+			importsDone.Broadcast()
+		}
+		importsMu.Unlock()
+	}()
+
+	return imports.Process(path, src, opt)
+}
+
 // Scaffold uses templates to scaffold new files
 type Scaffold interface {
-	// Execute writes to disk the provided files
-	Execute(*model.Universe, ...file.Builder) error
+	// Execute writes to disk the provided files and returns the changes performed on each of them
+	Execute(*model.Universe, ...file.Builder) ([]file.Change, error)
+	// Delete removes the code fragments of the provided Inserters and, for the rest of the builders, their
+	// files from disk, and returns the changes performed on each of them. Files and fragments that are not
+	// found are ignored.
+	Delete(*model.Universe, ...file.Builder) ([]file.Change, error)
 }
 
 // scaffold implements Scaffold interface
@@ -52,26 +95,56 @@ type scaffold struct {
 
 	// fs allows to mock the file system for tests
 	fs filesystem.FileSystem
+
+	// report collects the operations performed on each file, it may be nil
+	report *file.Report
+
+	// changes stores the operations performed on each file by the current execution
+	changes []file.Change
+
+	// insertions stores the code fragments inserted into (or removed from) each model by Inserters
+	insertions map[string][]file.Insertion
+
+	// updated is the operation recorded for the existing files updated by Inserters
+	updated file.Operation
+
+	// localPrefix is the repo of the current execution, whose imports are grouped apart
+	localPrefix string
+
+	// force are the classes of files that are overwritten if they already exist when they are expected not to
+	force map[file.Class]bool
+
+	// templates is the file system the overrides of the template bodies are read from, it may be nil
+	templates afero.Fs
 }
 
-// NewScaffold returns a new Scaffold with the provided plugins
-func NewScaffold(plugins ...model.Plugin) Scaffold {
+// NewScaffold returns a new Scaffold that writes to the provided filesystem with the provided plugins
+func NewScaffold(fs file.Filesystem, plugins ...model.Plugin) Scaffold {
+	if fs.FS == nil {
+		fs.FS = afero.NewOsFs()
+	}
+
+	force := make(map[file.Class]bool, len(fs.Force))
+	for _, class := range fs.Force {
+		force[class] = true
+	}
+
 	return &scaffold{
-		plugins: plugins,
-		fs:      filesystem.New(),
+		plugins:   plugins,
+		fs:        filesystem.New(filesystem.AferoFs(fs.FS)),
+		report:    fs.Report,
+		force:     force,
+		templates: fs.Templates,
 	}
 }
 
 // Execute implements Scaffold.Execute
-func (s *scaffold) Execute(universe *model.Universe, files ...file.Builder) error {
+func (s *scaffold) Execute(universe *model.Universe, files ...file.Builder) ([]file.Change, error) {
 	// Initialize the universe files
-	universe.Files = make(map[string]*file.File, len(files))
-
-	// Set the repo as the local prefix so that it knows how to group imports
-	if universe.Config != nil {
-		imports.LocalPrefix = universe.Config.Repo
-	}
+	s.reset(universe, len(files), file.InsertedInto)
 
+	// Templates are rendered concurrently, before any Inserter updates the models so that they see their contents
+	jobs := make([]renderJob, 0, len(files))
 	for _, f := range files {
 		// Inject common fields
 		universe.InjectInto(f)
@@ -79,60 +152,179 @@ func (s *scaffold) Execute(universe *model.Universe, files ...file.Builder) erro
 		// Validate file builders
 		if reqValFile, requiresValidation := f.(file.RequiresValidation); requiresValidation {
 			if err := reqValFile.Validate(); err != nil {
-				return file.NewValidateError(err)
+				return nil, file.NewValidateError(err)
 			}
 		}
 
 		// Build models for Template builders
 		if t, isTemplate := f.(file.Template); isTemplate {
-			if err := s.buildFileModel(t, universe.Files); err != nil {
-				return err
+			job, err := s.buildFileModel(t, universe.Files)
+			if err != nil {
+				return nil, err
+			}
+			if job != nil {
+				jobs = append(jobs, *job)
 			}
 		}
 
-		// Build models for Inserter builders
-		if i, isInserter := f.(file.Inserter); isInserter {
-			if err := s.updateFileModel(i, universe.Files); err != nil {
-				return err
+		// Build models for Inserter and YAMLInserter builders, in order, once the previous templates are rendered
+		switch b := f.(type) {
+		case file.Inserter, file.YAMLInserter:
+			if err := renderFileModels(jobs); err != nil {
+				return nil, err
+			}
+			jobs = jobs[:0]
+
+			if i, isInserter := b.(file.Inserter); isInserter {
+				if err := s.updateFileModel(i, universe.Files); err != nil {
+					return nil, err
+				}
+			}
+			if y, isYAMLInserter := b.(file.YAMLInserter); isYAMLInserter {
+				if err := s.updateYAMLFileModel(y, universe.Files); err != nil {
+					return nil, err
+				}
 			}
 		}
 	}
+	if err := renderFileModels(jobs); err != nil {
+		return nil, err
+	}
 
 	// Execute plugins
 	for _, plugin := range s.plugins {
 		if err := plugin.Pipe(universe); err != nil {
-			return model.NewPluginError(err)
+			return nil, model.NewPluginError(err)
 		}
 	}
 
 	// Persist the files to disk
-	for _, f := range universe.Files {
-		if err := s.writeFile(f); err != nil {
-			return err
+	if err := s.writeFiles(sortedModels(universe.Files)); err != nil {
+		return nil, err
+	}
+
+	return s.changes, nil
+}
+
+// Delete implements Scaffold.Delete
+func (s *scaffold) Delete(universe *model.Universe, files ...file.Builder) ([]file.Change, error) {
+	s.reset(universe, len(files), file.RemovedFrom)
+
+	removals := make([]string, 0, len(files))
+	for _, f := range files {
+		// Inject common fields
+		universe.InjectInto(f)
+
+		// Validate file builders
+		if reqValFile, requiresValidation := f.(file.RequiresValidation); requiresValidation {
+			if err := reqValFile.Validate(); err != nil {
+				return nil, file.NewValidateError(err)
+			}
+		}
+
+		// Set the template default values, which include the path
+		if t, isTemplate := f.(file.Template); isTemplate {
+			if err := t.SetTemplateDefaults(); err != nil {
+				return nil, file.NewSetTemplateDefaultsError(err)
+			}
+		}
+
+		// Inserter builders remove their code fragments, YAMLInserter builders their entries,
+		// other builders remove their files
+		switch b := f.(type) {
+		case file.Inserter:
+			if err := s.removeFromFileModel(b, universe.Files); err != nil {
+				return nil, err
+			}
+		case file.YAMLInserter:
+			if err := s.removeFromYAMLFileModel(b, universe.Files); err != nil {
+				return nil, err
+			}
+		default:
+			removals = append(removals, f.GetPath())
 		}
 	}
 
-	return nil
+	// Remove the files from disk
+	removed := make(map[string]bool, len(removals))
+	for _, path := range removals {
+		if removed[path] {
+			continue
+		}
+		exists, err := s.fs.Exists(path)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			continue
+		}
+		if err := s.fs.Remove(path); err != nil {
+			return nil, err
+		}
+		removed[path] = true
+		s.record(path, file.Deleted)
+	}
+
+	// Persist the updated files to disk
+	updated := make([]*file.File, 0, len(universe.Files))
+	for _, f := range sortedModels(universe.Files) {
+		if !removed[f.Path] {
+			updated = append(updated, f)
+		}
+	}
+	if err := s.writeFiles(updated); err != nil {
+		return nil, err
+	}
+
+	return s.changes, nil
+}
+
+// reset prepares the scaffold for a new execution over universe with the expected number of files
+func (s *scaffold) reset(universe *model.Universe, files int, updated file.Operation) {
+	// Initialize the universe files
+	universe.Files = make(map[string]*file.File, files)
+	s.changes = nil
+	s.insertions = make(map[string][]file.Insertion, files)
+	s.updated = updated
+
+	// Set the repo as the local prefix so that it knows how to group imports
+	s.localPrefix = ""
+	if universe.Config != nil {
+		s.localPrefix = universe.Config.Repo
+	}
 }
 
-// buildFileModel scaffolds a single file
-func (scaffold) buildFileModel(t file.Template, models map[string]*file.File) error {
+// renderJob is a template whose model contents are still to be rendered
+type renderJob struct {
+	t file.Template
+	m *file.File
+
+	// body is the template body to render and override the name of the file it was read from, if any
+	body     string
+	override string
+
+	localPrefix string
+}
+
+// buildFileModel adds the model of a single file, returning the job that renders its contents
+// or nil if the file is skipped
+func (s scaffold) buildFileModel(t file.Template, models map[string]*file.File) (*renderJob, error) {
 	// Set the template default values
 	err := t.SetTemplateDefaults()
 	if err != nil {
-		return file.NewSetTemplateDefaultsError(err)
+		return nil, file.NewSetTemplateDefaultsError(err)
 	}
 
 	// Handle already existing models
 	if _, found := models[t.GetPath()]; found {
 		switch t.GetIfExistsAction() {
 		case file.Skip:
-			return nil
+			return nil, nil
 		case file.Error:
-			return modelAlreadyExistsError{t.GetPath()}
+			return nil, modelAlreadyExistsError{t.GetPath()}
 		case file.Overwrite:
 		default:
-			return unknownIfExistsActionError{t.GetPath(), t.GetIfExistsAction()}
+			return nil, unknownIfExistsActionError{t.GetPath(), t.GetIfExistsAction()}
 		}
 	}
 
@@ -141,19 +333,94 @@ func (scaffold) buildFileModel(t file.Template, models map[string]*file.File) er
 		IfExistsAction: t.GetIfExistsAction(),
 	}
 
-	b, err := doTemplate(t)
+	body, override, err := s.templateBody(t)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	m.Contents = string(b)
 
 	models[m.Path] = m
+	return &renderJob{t: t, m: m, body: body, override: override, localPrefix: s.localPrefix}, nil
+}
+
+// renderFileModels renders the contents of the models of the jobs with a bounded number of workers,
+// returning the error of the first job that failed, in order
+func renderFileModels(jobs []renderJob) error {
+	workers := runtime.GOMAXPROCS(0)
+	if workers > len(jobs) {
+		workers = len(jobs)
+	}
+
+	errs := make([]error, len(jobs))
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				errs[i] = jobs[i].render()
+			}
+		}()
+	}
+	for i := range jobs {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// render executes the template of the job into the contents of its model
+func (j renderJob) render() error {
+	b, err := doTemplate(j.t, j.body, j.localPrefix)
+	if err != nil {
+		if j.override != "" {
+			return fmt.Errorf("unable to render the template override %s: %w", j.override, err)
+		}
+		return err
+	}
+	j.m.Contents = string(b)
 	return nil
 }
 
-// doTemplate executes the template for a file using the input
-func doTemplate(t file.Template) ([]byte, error) {
-	temp, err := newTemplate(t).Parse(t.GetBody())
+// templateBody returns the body of a template, which is overridden by the file of the templates file system named
+// after its output path or, otherwise, after its type, with the TemplateOverrideExt extension.
+// The name of the override is also returned, if any.
+func (s scaffold) templateBody(t file.Template) (string, string, error) {
+	if s.templates == nil {
+		return t.GetBody(), "", nil
+	}
+
+	for _, name := range []string{t.GetPath() + TemplateOverrideExt, TemplateKey(t) + TemplateOverrideExt} {
+		b, err := afero.ReadFile(s.templates, name)
+		if err == nil {
+			return string(b), name, nil
+		}
+		if !os.IsNotExist(err) {
+			return "", "", fmt.Errorf("unable to read the template override %s: %w", name, err)
+		}
+	}
+	return t.GetBody(), "", nil
+}
+
+// TemplateOverrideExt is the extension of the files overriding the template bodies
+const TemplateOverrideExt = ".tmpl"
+
+// TemplateKey returns the name the body of a template is overridden by, which is its type qualified by its
+// package name, e.g. "templates.Main"
+func TemplateKey(t file.Template) string {
+	return strings.TrimPrefix(fmt.Sprintf("%T", t), "*")
+}
+
+// doTemplate executes the body of the template for a file using the input
+func doTemplate(t file.Template, body, localPrefix string) ([]byte, error) {
+	temp, err := newTemplate(t).Parse(body)
 	if err != nil {
 		return nil, err
 	}
@@ -168,7 +435,7 @@ func doTemplate(t file.Template) ([]byte, error) {
 	// TODO(adirio): move go-formatting to write step
 	// gofmt the imports
 	if filepath.Ext(t.GetPath()) == ".go" {
-		b, err = imports.Process(t.GetPath(), b, &options)
+		b, err = processImports(t.GetPath(), b, &options, localPrefix)
 		if err != nil {
 			return nil, err
 		}
@@ -198,17 +465,20 @@ func (s scaffold) updateFileModel(i file.Inserter, models map[string]*file.File)
 	codeFragments := getValidCodeFragments(i)
 
 	// Remove code fragments that already were applied
-	err = filterExistingValues(m.Contents, codeFragments)
-	if err != nil {
-		return err
-	}
+	filterExistingValues(m.Contents, codeFragments)
 
 	// If no code fragment to insert, we are done
 	if len(codeFragments) == 0 {
 		return nil
 	}
 
-	content, err := insertStrings(m.Contents, codeFragments)
+	// Go inserters locate where to insert their code fragments by analyzing the code
+	var content []byte
+	if g, isGoInserter := i.(file.GoInserter); isGoInserter && filepath.Ext(i.GetPath()) == ".go" {
+		content, err = insertGoStrings(i.GetPath(), m.Contents, codeFragments, g.GetInsertionPoints())
+	} else {
+		content, err = insertStrings(i.GetPath(), m.Contents, codeFragments)
+	}
 	if err != nil {
 		return err
 	}
@@ -216,7 +486,7 @@ func (s scaffold) updateFileModel(i file.Inserter, models map[string]*file.File)
 	// TODO(adirio): move go-formatting to write step
 	formattedContent := content
 	if ext := filepath.Ext(i.GetPath()); ext == ".go" {
-		formattedContent, err = imports.Process(i.GetPath(), content, nil)
+		formattedContent, err = processImports(i.GetPath(), content, nil, s.localPrefix)
 		if err != nil {
 			return err
 		}
@@ -225,11 +495,122 @@ func (s scaffold) updateFileModel(i file.Inserter, models map[string]*file.File)
 	m.Contents = string(formattedContent)
 	m.IfExistsAction = file.Overwrite
 	models[m.Path] = m
+	s.insertions[m.Path] = append(s.insertions[m.Path], newInsertions(codeFragments)...)
+	return nil
+}
+
+// removeFromFileModel removes the code fragments of an Inserter from a single file, if it exists
+func (s scaffold) removeFromFileModel(i file.Inserter, models map[string]*file.File) error {
+	if _, found := models[i.GetPath()]; !found {
+		exists, err := s.fs.Exists(i.GetPath())
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return nil
+		}
+	}
+
+	m, err := s.loadPreviousModel(i, models)
+	if err != nil {
+		return err
+	}
+
+	content, codeFragments := removeStrings(m.Contents, getValidCodeFragments(i))
+
+	// If no code fragment was found, we are done
+	if len(codeFragments) == 0 {
+		return nil
+	}
+
+	if ext := filepath.Ext(i.GetPath()); ext == ".go" {
+		content, err = processImports(i.GetPath(), content, nil, s.localPrefix)
+		if err != nil {
+			return err
+		}
+	}
+
+	m.Contents = string(content)
+	m.IfExistsAction = file.Overwrite
+	models[m.Path] = m
+	s.insertions[m.Path] = append(s.insertions[m.Path], newInsertions(codeFragments)...)
+	return nil
+}
+
+// updateYAMLFileModel inserts the entries of a YAMLInserter into a single file
+func (s scaffold) updateYAMLFileModel(y file.YAMLInserter, models map[string]*file.File) error {
+	m, err := s.loadPreviousModel(y, models)
+	if err != nil {
+		return err
+	}
+
+	doc := newYAMLDocument(m.Path, m.Contents)
+	lines := make(map[string][]string)
+	for _, entry := range y.GetEntries() {
+		line, err := doc.insert(entry)
+		if err != nil {
+			return err
+		}
+		if line != "" {
+			lines[yamlInsertionMarker(entry)] = append(lines[yamlInsertionMarker(entry)], line+"\n")
+		}
+	}
+
+	// If no entry was inserted, we are done
+	if len(lines) == 0 {
+		return nil
+	}
+
+	m.Contents = doc.String()
+	m.IfExistsAction = file.Overwrite
+	models[m.Path] = m
+	s.insertions[m.Path] = append(s.insertions[m.Path], newYAMLInsertions(lines)...)
+	return nil
+}
+
+// removeFromYAMLFileModel removes the entries of a YAMLInserter from a single file, if it exists
+func (s scaffold) removeFromYAMLFileModel(y file.YAMLInserter, models map[string]*file.File) error {
+	if _, found := models[y.GetPath()]; !found {
+		exists, err := s.fs.Exists(y.GetPath())
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return nil
+		}
+	}
+
+	m, err := s.loadPreviousModel(y, models)
+	if err != nil {
+		return err
+	}
+
+	doc := newYAMLDocument(m.Path, m.Contents)
+	lines := make(map[string][]string)
+	for _, entry := range y.GetEntries() {
+		line, err := doc.remove(entry)
+		if err != nil {
+			return err
+		}
+		if line != "" {
+			lines[yamlInsertionMarker(entry)] = append(lines[yamlInsertionMarker(entry)], line+"\n")
+		}
+	}
+
+	// If no entry was found, we are done
+	if len(lines) == 0 {
+		return nil
+	}
+
+	m.Contents = doc.String()
+	m.IfExistsAction = file.Overwrite
+	models[m.Path] = m
+	s.insertions[m.Path] = append(s.insertions[m.Path], newYAMLInsertions(lines)...)
 	return nil
 }
 
 // loadPreviousModel gets the previous model from the models map or the actual file
-func (s scaffold) loadPreviousModel(i file.Inserter, models map[string]*file.File) (*file.File, error) {
+func (s scaffold) loadPreviousModel(i file.Builder, models map[string]*file.File) (*file.File, error) {
 	// Lets see if we already have a model for this file
 	if m, found := models[i.GetPath()]; found {
 		// Check if there is already an scaffolded file
@@ -312,31 +693,60 @@ func getValidCodeFragments(i file.Inserter) file.CodeFragmentsMap {
 	return codeFragments
 }
 
-// filterExistingValues removes the single-line values that already exists
-// TODO: Add support for multi-line duplicate values
-func filterExistingValues(content string, codeFragmentsMap file.CodeFragmentsMap) error {
-	scanner := bufio.NewScanner(strings.NewReader(content))
-	for scanner.Scan() {
-		line := scanner.Text()
-		for marker, codeFragments := range codeFragmentsMap {
-			for i, codeFragment := range codeFragments {
-				if strings.TrimSpace(line) == strings.TrimSpace(codeFragment) {
-					codeFragmentsMap[marker] = append(codeFragments[:i], codeFragments[i+1:]...)
-				}
-			}
-			if len(codeFragmentsMap[marker]) == 0 {
-				delete(codeFragmentsMap, marker)
+// filterExistingValues removes the values that already exist
+// Lines are compared as in removeStrings, the trailing empty lines of the values are ignored
+func filterExistingValues(content string, codeFragmentsMap file.CodeFragmentsMap) {
+	lines := strings.SplitAfter(content, "\n")
+	for marker, codeFragments := range codeFragmentsMap {
+		missing := make(file.CodeFragments, 0, len(codeFragments))
+		for _, codeFragment := range codeFragments {
+			fragmentLines := strings.Split(strings.TrimRight(codeFragment, "\n"), "\n")
+			if findLines(lines, fragmentLines) == -1 {
+				missing = append(missing, codeFragment)
 			}
 		}
+
+		if len(missing) == 0 {
+			delete(codeFragmentsMap, marker)
+		} else {
+			codeFragmentsMap[marker] = missing
+		}
 	}
-	if err := scanner.Err(); err != nil {
-		return err
+}
+
+// yamlInsertionMarker returns the marker an entry is recorded under, which is its list key if it has no marker
+func yamlInsertionMarker(entry file.YAMLEntry) string {
+	if entry.Marker != (file.Marker{}) {
+		return entry.Marker.String()
 	}
-	return nil
+	return entry.Key
 }
 
-func insertStrings(content string, codeFragmentsMap file.CodeFragmentsMap) ([]byte, error) {
+// newYAMLInsertions returns the insertions for the lines of YAML entries, sorted by marker
+func newYAMLInsertions(lines map[string][]string) []file.Insertion {
+	insertions := make([]file.Insertion, 0, len(lines))
+	for marker, fragments := range lines {
+		insertions = append(insertions, file.Insertion{Marker: marker, Fragments: fragments})
+	}
+	sort.Slice(insertions, func(i, j int) bool { return insertions[i].Marker < insertions[j].Marker })
+	return insertions
+}
+
+// newInsertions returns the insertions for the code fragments, sorted by marker
+func newInsertions(codeFragmentsMap file.CodeFragmentsMap) []file.Insertion {
+	insertions := make([]file.Insertion, 0, len(codeFragmentsMap))
+	for marker, codeFragments := range codeFragmentsMap {
+		insertions = append(insertions, file.Insertion{Marker: marker.String(), Fragments: codeFragments})
+	}
+	sort.Slice(insertions, func(i, j int) bool { return insertions[i].Marker < insertions[j].Marker })
+	return insertions
+}
+
+// insertStrings inserts the code fragments into content before their markers.
+// It fails if any of the markers is not found.
+func insertStrings(path, content string, codeFragmentsMap file.CodeFragmentsMap) ([]byte, error) {
 	out := new(bytes.Buffer)
+	found := make(map[file.Marker]bool, len(codeFragmentsMap))
 
 	scanner := bufio.NewScanner(strings.NewReader(content))
 	for scanner.Scan() {
@@ -344,6 +754,7 @@ func insertStrings(content string, codeFragmentsMap file.CodeFragmentsMap) ([]by
 
 		for marker, codeFragments := range codeFragmentsMap {
 			if strings.TrimSpace(line) == strings.TrimSpace(marker.String()) {
+				found[marker] = true
 				for _, codeFragment := range codeFragments {
 					_, _ = out.WriteString(codeFragment) // bytes.Buffer.WriteString always returns nil errors
 				}
@@ -356,21 +767,165 @@ func insertStrings(content string, codeFragmentsMap file.CodeFragmentsMap) ([]by
 		return nil, err
 	}
 
+	markers := make([]file.Marker, 0, len(codeFragmentsMap))
+	for marker := range codeFragmentsMap {
+		if !found[marker] {
+			markers = append(markers, marker)
+		}
+	}
+	if len(markers) != 0 {
+		sort.Slice(markers, func(i, j int) bool { return markers[i].String() < markers[j].String() })
+		return nil, insertionPointNotFoundError{path, markers[0], "the marker was not found"}
+	}
+
 	return out.Bytes(), nil
 }
 
-func (s scaffold) writeFile(f *file.File) error {
+// removeStrings removes the first occurrence of each code fragment from content and returns the
+// resulting content along with the code fragments that were removed
+// Lines are compared ignoring the amount of whitespace, as formatting may have aligned them, and
+// commented out fragments also match once uncommented
+func removeStrings(content string, codeFragmentsMap file.CodeFragmentsMap) ([]byte, file.CodeFragmentsMap) {
+	lines := strings.SplitAfter(content, "\n")
+	removed := make(file.CodeFragmentsMap)
+	for marker, codeFragments := range codeFragmentsMap {
+		for _, codeFragment := range codeFragments {
+			fragmentLines := strings.Split(strings.TrimSuffix(codeFragment, "\n"), "\n")
+			if start := findLines(lines, fragmentLines); start != -1 {
+				lines = append(lines[:start], lines[start+len(fragmentLines):]...)
+				removed[marker] = append(removed[marker], codeFragment)
+			}
+		}
+	}
+
+	return []byte(strings.Join(lines, "")), removed
+}
+
+// findLines returns the index of the first line of the contiguous lines matching fragmentLines, or -1
+func findLines(lines, fragmentLines []string) int {
+	for start := 0; start+len(fragmentLines) <= len(lines); start++ {
+		matches := true
+		for i, fragmentLine := range fragmentLines {
+			if !linesMatch(lines[start+i], fragmentLine) {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			return start
+		}
+	}
+	return -1
+}
+
+// linesMatch checks if line is the fragment line, ignoring whitespace and a leading comment of the fragment line
+func linesMatch(line, fragmentLine string) bool {
+	line = strings.Join(strings.Fields(line), " ")
+	fragmentLine = strings.Join(strings.Fields(fragmentLine), " ")
+	if line == fragmentLine {
+		return true
+	}
+	for _, comment := range []string{"#", "//"} {
+		if uncommented := strings.TrimPrefix(fragmentLine, comment); uncommented != fragmentLine {
+			return line == strings.TrimSpace(uncommented)
+		}
+	}
+	return false
+}
+
+// sortedModels returns the models sorted by path, so that they are always written in the same order
+func sortedModels(models map[string]*file.File) []*file.File {
+	files := make([]*file.File, 0, len(models))
+	for _, m := range models {
+		files = append(files, m)
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].Path < files[j].Path })
+	return files
+}
+
+// writeFiles writes the files in order, checking first which of them already exist when they are expected not
+// to. The files of the forced classes are overwritten and the rest are conflicts. If there is a report, the
+// conflicts are added to it and the rest of the files are written, so that the conflicts of every scaffold of a
+// command are found at once. Otherwise, no file is written and the conflicts are returned as an error.
+func (s *scaffold) writeFiles(files []*file.File) error {
+	var conflicts []file.Conflict
+	writes := make([]*file.File, 0, len(files))
+	for _, f := range files {
+		conflict, err := s.checkConflict(f)
+		if err != nil {
+			return err
+		}
+		if conflict != nil {
+			conflicts = append(conflicts, *conflict)
+			continue
+		}
+		writes = append(writes, f)
+	}
+
+	if len(conflicts) != 0 {
+		if s.report == nil {
+			return file.NewConflictsError(conflicts)
+		}
+		for _, conflict := range conflicts {
+			s.report.AddConflict(conflict)
+		}
+	}
+
+	for _, f := range writes {
+		if err := s.writeFile(f); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkConflict returns the conflict if f already exists with different contents when expected not to,
+// unless its class is forced, in which case it is overwritten
+func (s *scaffold) checkConflict(f *file.File) (*file.Conflict, error) {
+	if f.IfExistsAction != file.Error {
+		return nil, nil
+	}
+	exists, err := s.fs.Exists(f.Path)
+	if err != nil || !exists {
+		return nil, err
+	}
+
+	class := file.ClassOf(f.Path)
+	if s.force[class] {
+		f.IfExistsAction = file.Overwrite
+		return nil, nil
+	}
+
+	current, err := s.loadModelFromFile(f.Path)
+	if err != nil {
+		return nil, err
+	}
+	// Files that were already scaffolded the same way are not conflicts
+	if current.Contents == f.Contents {
+		f.IfExistsAction = file.Skip
+		return nil, nil
+	}
+	return &file.Conflict{Path: f.Path, Class: class, Diff: unifiedDiff(f.Path, current.Contents, f.Contents)}, nil
+}
+
+func (s *scaffold) writeFile(f *file.File) error {
 	// Check if the file to write already exists
 	exists, err := s.fs.Exists(f.Path)
 	if err != nil {
 		return err
 	}
+	operation := file.Created
 	if exists {
 		switch f.IfExistsAction {
 		case file.Overwrite:
 			// By not returning, the file is written as if it didn't exist
+			operation = file.Overwritten
+			if len(s.insertions[f.Path]) != 0 {
+				operation = s.updated
+			}
 		case file.Skip:
 			// By returning nil, the file is not written but the process will carry on
+			s.record(f.Path, file.Skipped)
 			return nil
 		case file.Error:
 			// By returning an error, the file is not written and the process will fail
@@ -383,7 +938,23 @@ func (s scaffold) writeFile(f *file.File) error {
 		return err
 	}
 
-	_, err = writer.Write([]byte(f.Contents))
+	if _, err = writer.Write([]byte(f.Contents)); err != nil {
+		return err
+	}
+
+	s.record(f.Path, operation)
+	return nil
+}
+
+// record adds the operation performed on a file to the changes and to the report, if any
+func (s *scaffold) record(path string, operation file.Operation) {
+	change := file.Change{Path: path, Operation: operation}
+	if operation != file.Skipped {
+		change.Insertions = s.insertions[path]
+	}
 
-	return err
+	s.changes = append(s.changes, change)
+	if s.report != nil {
+		s.report.Add(change)
+	}
 }
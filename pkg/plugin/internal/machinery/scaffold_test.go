@@ -17,12 +17,17 @@ package machinery
 import (
 	"bytes"
 	"errors"
+	"fmt"
+	"strings"
+	"sync"
 	"testing"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/ginkgo/extensions/table"
 	. "github.com/onsi/gomega"
 
+	"github.com/spf13/afero"
+
 	"sigs.k8s.io/kubebuilder/pkg/model"
 	"sigs.k8s.io/kubebuilder/pkg/model/file"
 	"sigs.k8s.io/kubebuilder/pkg/plugin/internal/filesystem"
@@ -43,7 +48,7 @@ var _ = Describe("Scaffold", func() {
 
 		Context("when using no plugins", func() {
 			BeforeEach(func() {
-				si = NewScaffold()
+				si = NewScaffold(file.Filesystem{FS: afero.NewMemMapFs()})
 				s, ok = si.(*scaffold)
 			})
 
@@ -62,7 +67,7 @@ var _ = Describe("Scaffold", func() {
 
 		Context("when using one plugin", func() {
 			BeforeEach(func() {
-				si = NewScaffold(fakePlugin{})
+				si = NewScaffold(file.Filesystem{FS: afero.NewMemMapFs()}, fakePlugin{})
 				s, ok = si.(*scaffold)
 			})
 
@@ -81,7 +86,7 @@ var _ = Describe("Scaffold", func() {
 
 		Context("when using several plugins", func() {
 			BeforeEach(func() {
-				si = NewScaffold(fakePlugin{}, fakePlugin{}, fakePlugin{})
+				si = NewScaffold(file.Filesystem{FS: afero.NewMemMapFs()}, fakePlugin{}, fakePlugin{}, fakePlugin{})
 				s, ok = si.(*scaffold)
 			})
 
@@ -119,7 +124,8 @@ var _ = Describe("Scaffold", func() {
 					),
 				}
 
-				Expect(s.Execute(model.NewUniverse(), files...)).To(Succeed())
+				_, err := s.Execute(model.NewUniverse(), files...)
+				Expect(err).NotTo(HaveOccurred())
 				Expect(output.String()).To(Equal(expected))
 			},
 			Entry("should write the file",
@@ -146,7 +152,8 @@ var _ = Describe("Scaffold", func() {
 			func(f func(error) bool, files ...file.Builder) {
 				s := &scaffold{fs: filesystem.NewMock()}
 
-				Expect(f(s.Execute(model.NewUniverse(), files...))).To(BeTrue())
+				_, err := s.Execute(model.NewUniverse(), files...)
+				Expect(f(err)).To(BeTrue())
 			},
 			Entry("should fail if unable to validate a file builder",
 				file.IsValidateError,
@@ -173,7 +180,7 @@ var _ = Describe("Scaffold", func() {
 			func(errMsg string, files ...file.Builder) {
 				s := &scaffold{fs: filesystem.NewMock()}
 
-				err := s.Execute(model.NewUniverse(), files...)
+				_, err := s.Execute(model.NewUniverse(), files...)
 				Expect(err).To(HaveOccurred())
 				Expect(err.Error()).To(ContainSubstring(errMsg))
 			},
@@ -201,7 +208,8 @@ var _ = Describe("Scaffold", func() {
 					),
 				}
 
-				Expect(s.Execute(model.NewUniverse(), files...)).To(Succeed())
+				_, err := s.Execute(model.NewUniverse(), files...)
+				Expect(err).NotTo(HaveOccurred())
 				Expect(output.String()).To(Equal(expected))
 			},
 			Entry("should insert lines for go files",
@@ -316,6 +324,27 @@ var _ = Describe("Scaffold", func() {
 					},
 				},
 			),
+			Entry("should filter already existing multi-line code fragments",
+				`
+1
+  2
+
+// +kubebuilder:scaffold:-
+`,
+				`
+1
+  2
+
+3
+4
+// +kubebuilder:scaffold:-
+`,
+				fakeInserter{
+					codeFragments: file.CodeFragmentsMap{
+						file.NewMarkerFor("file.go", "-"): {"1\n2\n\n", "3\n4\n"},
+					},
+				},
+			),
 			Entry("should not insert anything if no code fragment",
 				"", // input is provided through a template as mock fs doesn't copy it to the output buffer if no-op
 				`
@@ -340,7 +369,7 @@ var _ = Describe("Scaffold", func() {
 					),
 				}
 
-				err := s.Execute(model.NewUniverse(), files...)
+				_, err := s.Execute(model.NewUniverse(), files...)
 				Expect(err).To(HaveOccurred())
 				Expect(f(err)).To(BeTrue())
 			},
@@ -354,6 +383,15 @@ var _ = Describe("Scaffold", func() {
 				fakeTemplate{fakeBuilder: fakeBuilder{path: "filename", ifExistsAction: -1}},
 				fakeInserter{fakeBuilder: fakeBuilder{path: "filename"}},
 			),
+			Entry("should fail if the marker of a code fragment is not found",
+				IsInsertionPointNotFoundError,
+				fakeInserter{
+					fakeBuilder: fakeBuilder{path: "file.yaml"},
+					codeFragments: file.CodeFragmentsMap{
+						file.NewMarkerFor("file.yaml", "-"): {"1\n"},
+					},
+				},
+			),
 		)
 
 		It("should fail if a plugin fails", func() {
@@ -362,7 +400,7 @@ var _ = Describe("Scaffold", func() {
 				plugins: []model.Plugin{fakePlugin{err: testErr}},
 			}
 
-			err := s.Execute(
+			_, err := s.Execute(
 				model.NewUniverse(),
 				fakeTemplate{},
 			)
@@ -383,32 +421,295 @@ var _ = Describe("Scaffold", func() {
 			})
 
 			It("should skip the file by default", func() {
-				Expect(s.Execute(
+				_, err := s.Execute(
 					model.NewUniverse(),
 					fakeTemplate{body: fileContent},
-				)).To(Succeed())
+				)
+				Expect(err).NotTo(HaveOccurred())
 				Expect(output.String()).To(BeEmpty())
 			})
 
 			It("should write the file if configured to do so", func() {
-				Expect(s.Execute(
+				_, err := s.Execute(
 					model.NewUniverse(),
 					fakeTemplate{fakeBuilder: fakeBuilder{ifExistsAction: file.Overwrite}, body: fileContent},
-				)).To(Succeed())
+				)
+				Expect(err).NotTo(HaveOccurred())
 				Expect(output.String()).To(Equal(fileContent))
 			})
 
 			It("should error if configured to do so", func() {
-				err := s.Execute(
+				s = &scaffold{
+					fs: filesystem.NewMock(
+						filesystem.MockInput(bytes.NewBufferString("Hello there!")),
+						filesystem.MockExists(func(_ string) bool { return true }),
+						filesystem.MockOutput(&output),
+					),
+				}
+
+				_, err := s.Execute(
 					model.NewUniverse(),
 					fakeTemplate{fakeBuilder: fakeBuilder{path: "filename", ifExistsAction: file.Error}, body: fileContent},
 				)
 				Expect(err).To(HaveOccurred())
-				Expect(IsFileAlreadyExistsError(err)).To(BeTrue())
+				Expect(file.IsConflictsError(err)).To(BeTrue())
+				Expect(output.String()).To(BeEmpty())
+			})
+
+			It("should skip the file if it has the same contents", func() {
+				_, err := s.Execute(
+					model.NewUniverse(),
+					fakeTemplate{fakeBuilder: fakeBuilder{path: "filename", ifExistsAction: file.Error}, body: fileContent},
+				)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(output.String()).To(BeEmpty())
+			})
+
+			It("should overwrite the file if its class is forced", func() {
+				s = &scaffold{
+					fs: filesystem.NewMock(
+						filesystem.MockExists(func(_ string) bool { return true }),
+						filesystem.MockOutput(&output),
+					),
+					force: map[file.Class]bool{file.SamplesClass: true},
+				}
+
+				_, err := s.Execute(
+					model.NewUniverse(),
+					fakeTemplate{
+						fakeBuilder: fakeBuilder{path: "config/samples/sample.yaml", ifExistsAction: file.Error},
+						body:        fileContent,
+					},
+				)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(output.String()).To(Equal(fileContent))
+			})
+
+			It("should not write any file if one of them errors", func() {
+				s = &scaffold{
+					fs: filesystem.NewMock(
+						filesystem.MockInput(bytes.NewBufferString("Hello there!")),
+						filesystem.MockExists(func(path string) bool { return path == "b" }),
+						filesystem.MockOutput(&output),
+					),
+				}
+
+				_, err := s.Execute(
+					model.NewUniverse(),
+					fakeTemplate{fakeBuilder: fakeBuilder{path: "a"}, body: fileContent},
+					fakeTemplate{fakeBuilder: fakeBuilder{path: "b", ifExistsAction: file.Error}, body: fileContent},
+				)
+				Expect(err).To(HaveOccurred())
+				Expect(file.IsConflictsError(err)).To(BeTrue())
+				Expect(output.String()).To(BeEmpty())
+			})
+		})
+
+		Context("reporting the performed operations", func() {
+			var report *file.Report
+
+			BeforeEach(func() {
+				report = &file.Report{}
+			})
+
+			It("should report created files", func() {
+				s := &scaffold{fs: filesystem.NewMock(filesystem.MockOutput(&output)), report: report}
+
+				_, err := s.Execute(
+					model.NewUniverse(),
+					fakeTemplate{fakeBuilder: fakeBuilder{path: "filename"}, body: fileContent},
+				)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(report.Changes()).To(Equal([]file.Change{{Path: "filename", Operation: file.Created}}))
+			})
+
+			It("should report the conflicts with their diffs and write the rest of the files", func() {
+				s := &scaffold{
+					fs: filesystem.NewMock(
+						filesystem.MockInput(bytes.NewBufferString("Hello there!\n")),
+						filesystem.MockExists(func(path string) bool { return path == "existing" }),
+						filesystem.MockOutput(&output),
+					),
+					report: report,
+				}
+
+				_, err := s.Execute(
+					model.NewUniverse(),
+					fakeTemplate{fakeBuilder: fakeBuilder{path: "created"}, body: fileContent},
+					fakeTemplate{fakeBuilder: fakeBuilder{path: "existing", ifExistsAction: file.Error}, body: fileContent},
+				)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(output.String()).To(Equal(fileContent))
+				Expect(report.Changes()).To(Equal([]file.Change{{Path: "created", Operation: file.Created}}))
+				Expect(report.Conflicts()).To(Equal([]file.Conflict{{
+					Path:  "existing",
+					Class: file.OtherClass,
+					Diff:  "--- existing\n+++ existing (scaffolded)\n@@ -1,1 +1,1 @@\n-Hello there!\n+Hello world!\n",
+				}}))
+			})
+
+			It("should report skipped and overwritten files", func() {
+				s := &scaffold{
+					fs: filesystem.NewMock(
+						filesystem.MockExists(func(_ string) bool { return true }),
+						filesystem.MockOutput(&output),
+					),
+					report: report,
+				}
+
+				_, err := s.Execute(
+					model.NewUniverse(),
+					fakeTemplate{fakeBuilder: fakeBuilder{path: "skipped"}, body: fileContent},
+				)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = s.Execute(
+					model.NewUniverse(),
+					fakeTemplate{fakeBuilder: fakeBuilder{path: "overwritten", ifExistsAction: file.Overwrite}},
+				)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(report.Changes()).To(Equal([]file.Change{
+					{Path: "skipped", Operation: file.Skipped},
+					{Path: "overwritten", Operation: file.Overwritten},
+				}))
+			})
+
+			It("should report the fragments inserted by an inserter", func() {
+				s := &scaffold{
+					fs: filesystem.NewMock(
+						filesystem.MockInput(bytes.NewBufferString("// +kubebuilder:scaffold:-\n")),
+						filesystem.MockExists(func(_ string) bool { return true }),
+						filesystem.MockOutput(&output),
+					),
+					report: report,
+				}
+
+				changes, err := s.Execute(
+					model.NewUniverse(),
+					fakeInserter{
+						fakeBuilder: fakeBuilder{path: "filename"},
+						codeFragments: file.CodeFragmentsMap{
+							file.NewMarkerFor("file.go", "-"): {"1\n"},
+						},
+					},
+				)
+				Expect(err).NotTo(HaveOccurred())
+				expected := []file.Change{{
+					Path:      "filename",
+					Operation: file.InsertedInto,
+					Insertions: []file.Insertion{
+						{Marker: file.NewMarkerFor("file.go", "-").String(), Fragments: []string{"1\n"}},
+					},
+				}}
+				Expect(changes).To(Equal(expected))
+				Expect(report.Changes()).To(Equal(expected))
+			})
+
+			It("should report the entries inserted by a YAML inserter", func() {
+				s := &scaffold{
+					fs: filesystem.NewMock(
+						filesystem.MockInput(bytes.NewBufferString("resources:\n- a\n")),
+						filesystem.MockExists(func(_ string) bool { return true }),
+						filesystem.MockOutput(&output),
+					),
+					report: report,
+				}
+
+				changes, err := s.Execute(
+					model.NewUniverse(),
+					fakeYAMLInserter{
+						fakeBuilder: fakeBuilder{path: "filename"},
+						entries:     []file.YAMLEntry{{Key: "resources", Value: "a"}, {Key: "resources", Value: "b"}},
+					},
+				)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(output.String()).To(Equal("resources:\n- a\n- b\n"))
+				expected := []file.Change{{
+					Path:       "filename",
+					Operation:  file.InsertedInto,
+					Insertions: []file.Insertion{{Marker: "resources", Fragments: []string{"- b\n"}}},
+				}}
+				Expect(changes).To(Equal(expected))
+				Expect(report.Changes()).To(Equal(expected))
+			})
+		})
+
+		Context("rendering many templates", func() {
+			It("should write and report the files sorted by path", func() {
+				report := &file.Report{}
+				s := &scaffold{fs: filesystem.NewMock(filesystem.MockOutput(&output)), report: report}
+
+				var (
+					files    []file.Builder
+					expected []file.Change
+					contents strings.Builder
+				)
+				for i := 99; i >= 0; i-- {
+					path := fmt.Sprintf("file%02d.go", i)
+					files = append(files, fakeTemplate{fakeBuilder: fakeBuilder{path: path}, body: "package    file"})
+				}
+				for i := 0; i < 100; i++ {
+					expected = append(expected, file.Change{Path: fmt.Sprintf("file%02d.go", i), Operation: file.Created})
+					contents.WriteString("package file\n")
+				}
+
+				changes, err := s.Execute(model.NewUniverse(), files...)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(output.String()).To(Equal(contents.String()))
+				Expect(changes).To(Equal(expected))
+				Expect(report.Changes()).To(Equal(expected))
+			})
+
+			It("should return the error of the first template that failed", func() {
+				s := &scaffold{fs: filesystem.NewMock(filesystem.MockOutput(&output))}
+
+				files := []file.Builder{fakeTemplate{fakeBuilder: fakeBuilder{path: "0"}, body: "{{ end }}"}}
+				for i := 1; i < 100; i++ {
+					files = append(files, fakeTemplate{fakeBuilder: fakeBuilder{path: fmt.Sprint(i)}, body: "{{ .Field }"})
+				}
+
+				_, err := s.Execute(model.NewUniverse(), files...)
+				Expect(err).To(MatchError(ContainSubstring("unexpected {{end}}")))
 				Expect(output.String()).To(BeEmpty())
 			})
 		})
 
+		Context("overriding the template bodies", func() {
+			var templates afero.Fs
+
+			BeforeEach(func() {
+				templates = afero.NewMemMapFs()
+				Expect(afero.WriteFile(templates, "machinery.fakeTemplate.tmpl", []byte("by type {{ .GetPath }}"), 0644)).
+					To(Succeed())
+				Expect(afero.WriteFile(templates, "overridden.tmpl", []byte("by path"), 0644)).To(Succeed())
+			})
+
+			DescribeTable("should use the override of the template",
+				func(path, expected string) {
+					s := &scaffold{
+						fs:        filesystem.NewMock(filesystem.MockOutput(&output)),
+						templates: templates,
+					}
+
+					_, err := s.Execute(model.NewUniverse(), fakeTemplate{fakeBuilder: fakeBuilder{path: path}, body: "default"})
+					Expect(err).NotTo(HaveOccurred())
+					Expect(output.String()).To(Equal(expected))
+				},
+				Entry("named after its output path", "overridden", "by path"),
+				Entry("named after its type", "other", "by type other"),
+			)
+
+			It("should name the override which failed to render", func() {
+				Expect(afero.WriteFile(templates, "broken.tmpl", []byte("{{ .Missing"), 0644)).To(Succeed())
+				s := &scaffold{
+					fs:        filesystem.NewMock(filesystem.MockOutput(&output)),
+					templates: templates,
+				}
+
+				_, err := s.Execute(model.NewUniverse(), fakeTemplate{fakeBuilder: fakeBuilder{path: "broken"}})
+				Expect(err).To(MatchError(ContainSubstring("unable to render the template override broken.tmpl")))
+			})
+		})
+
 		DescribeTable("filesystem errors",
 			func(
 				mockErrorF func(error) filesystem.MockOptions,
@@ -421,7 +722,7 @@ var _ = Describe("Scaffold", func() {
 					),
 				}
 
-				err := s.Execute(model.NewUniverse(), files...)
+				_, err := s.Execute(model.NewUniverse(), files...)
 				Expect(err).To(HaveOccurred())
 				Expect(checkErrorF(err)).To(BeTrue())
 			},
@@ -464,6 +765,192 @@ var _ = Describe("Scaffold", func() {
 			),
 		)
 	})
+	Describe("Scaffold.Delete", func() {
+		var output bytes.Buffer
+
+		BeforeEach(func() {
+			output.Reset()
+		})
+
+		DescribeTable("remove strings",
+			func(input, expected string, files ...file.Builder) {
+				s := &scaffold{
+					fs: filesystem.NewMock(
+						filesystem.MockInput(bytes.NewBufferString(input)),
+						filesystem.MockOutput(&output),
+						filesystem.MockExists(func(_ string) bool { return true }),
+					),
+				}
+
+				_, err := s.Delete(model.NewUniverse(), files...)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(output.String()).To(Equal(expected))
+			},
+			Entry("should remove lines for yaml files",
+				`
+1
+2
+# +kubebuilder:scaffold:-
+`,
+				`
+2
+# +kubebuilder:scaffold:-
+`,
+				fakeInserter{codeFragments: file.CodeFragmentsMap{
+					file.NewMarkerFor("file.yaml", "-"): {"1\n"}},
+				},
+			),
+			Entry("should remove multi-line fragments ignoring the whitespace",
+				`
+a:  1
+b:    2
+c: 3
+# +kubebuilder:scaffold:-
+`,
+				`
+c: 3
+# +kubebuilder:scaffold:-
+`,
+				fakeInserter{codeFragments: file.CodeFragmentsMap{
+					file.NewMarkerFor("file.yaml", "-"): {"a: 1\nb: 2\n"}},
+				},
+			),
+			Entry("should remove commented out fragments once uncommented",
+				`
+- 1
+#- 2
+# +kubebuilder:scaffold:-
+`,
+				`
+# +kubebuilder:scaffold:-
+`,
+				fakeInserter{codeFragments: file.CodeFragmentsMap{
+					file.NewMarkerFor("file.yaml", "-"): {"#- 1\n", "#- 2\n"}},
+				},
+			),
+			Entry("should not write the file if no fragment is found",
+				`
+# +kubebuilder:scaffold:-
+`,
+				"",
+				fakeInserter{codeFragments: file.CodeFragmentsMap{
+					file.NewMarkerFor("file.yaml", "-"): {"1\n"}},
+				},
+			),
+		)
+
+		It("should report deleted files and the fragments removed by an inserter", func() {
+			report := &file.Report{}
+			s := &scaffold{
+				fs: filesystem.NewMock(
+					filesystem.MockInput(bytes.NewBufferString("1\n// +kubebuilder:scaffold:-\n")),
+					filesystem.MockExists(func(path string) bool { return path != "missing" }),
+					filesystem.MockOutput(&output),
+				),
+				report: report,
+			}
+
+			changes, err := s.Delete(
+				model.NewUniverse(),
+				fakeTemplate{fakeBuilder: fakeBuilder{path: "deleted"}},
+				fakeTemplate{fakeBuilder: fakeBuilder{path: "missing"}},
+				fakeInserter{
+					fakeBuilder: fakeBuilder{path: "filename"},
+					codeFragments: file.CodeFragmentsMap{
+						file.NewMarkerFor("file.go", "-"): {"1\n"},
+					},
+				},
+			)
+			Expect(err).NotTo(HaveOccurred())
+			expected := []file.Change{
+				{Path: "deleted", Operation: file.Deleted},
+				{
+					Path:      "filename",
+					Operation: file.RemovedFrom,
+					Insertions: []file.Insertion{
+						{Marker: file.NewMarkerFor("file.go", "-").String(), Fragments: []string{"1\n"}},
+					},
+				},
+			}
+			Expect(changes).To(Equal(expected))
+			Expect(report.Changes()).To(Equal(expected))
+		})
+
+		It("should remove the entries of a YAML inserter", func() {
+			s := &scaffold{
+				fs: filesystem.NewMock(
+					filesystem.MockInput(bytes.NewBufferString("resources:\n- a\n#- b\n")),
+					filesystem.MockExists(func(_ string) bool { return true }),
+					filesystem.MockOutput(&output),
+				),
+			}
+
+			changes, err := s.Delete(
+				model.NewUniverse(),
+				fakeYAMLInserter{
+					fakeBuilder: fakeBuilder{path: "filename"},
+					entries:     []file.YAMLEntry{{Key: "resources", Value: "b"}},
+				},
+			)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(output.String()).To(Equal("resources:\n- a\n"))
+			Expect(changes).To(Equal([]file.Change{{
+				Path:       "filename",
+				Operation:  file.RemovedFrom,
+				Insertions: []file.Insertion{{Marker: "resources", Fragments: []string{"#- b\n"}}},
+			}}))
+		})
+
+		It("should fail if fs.Remove was unable to remove the file", func() {
+			s := &scaffold{
+				fs: filesystem.NewMock(
+					filesystem.MockExists(func(_ string) bool { return true }),
+					filesystem.MockRemoveFileError(errors.New("error text")),
+				),
+			}
+
+			_, err := s.Delete(model.NewUniverse(), fakeTemplate{})
+			Expect(err).To(HaveOccurred())
+			Expect(filesystem.IsRemoveFileError(err)).To(BeTrue())
+		})
+	})
+
+	Describe("processImports", func() {
+		It("should group the imports of each file with its own local prefix when run concurrently", func() {
+			const src = `package file
+
+import (
+	"a.com/a"
+	"b.com/b"
+	"fmt"
+)
+`
+			expected := map[string]string{
+				"a.com": "package file\n\nimport (\n\t\"fmt\"\n\n\t\"b.com/b\"\n\n\t\"a.com/a\"\n)\n",
+				"b.com": "package file\n\nimport (\n\t\"fmt\"\n\n\t\"a.com/a\"\n\n\t\"b.com/b\"\n)\n",
+			}
+
+			prefixes := []string{"a.com", "b.com"}
+
+			var wg sync.WaitGroup
+			results := make([]string, 50)
+			for i := range results {
+				wg.Add(1)
+				go func(i int) {
+					defer wg.Done()
+					defer GinkgoRecover()
+					b, err := processImports("file.go", []byte(src), &options, prefixes[i%2])
+					Expect(err).NotTo(HaveOccurred())
+					results[i] = string(b)
+				}(i)
+			}
+			wg.Wait()
+
+			for i, result := range results {
+				Expect(result).To(Equal(expected[prefixes[i%2]]))
+			}
+		})
+	})
 })
 
 var _ model.Plugin = fakePlugin{}
@@ -558,3 +1045,14 @@ func (f fakeInserter) GetMarkers() []file.Marker {
 func (f fakeInserter) GetCodeFragments() file.CodeFragmentsMap {
 	return f.codeFragments
 }
+
+type fakeYAMLInserter struct {
+	fakeBuilder
+
+	entries []file.YAMLEntry
+}
+
+// GetEntries implements file.YAMLInserter
+func (f fakeYAMLInserter) GetEntries() []file.YAMLEntry {
+	return f.entries
+}
@@ -0,0 +1,273 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machinery
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"sigs.k8s.io/kubebuilder/pkg/model/file"
+)
+
+// yamlDocument is a YAML document whose lists are located by parsing it, and which is edited line by line,
+// so that the lines that are not modified, comments included, are kept as they are
+type yamlDocument struct {
+	path  string
+	lines []string
+}
+
+// yamlList is the region of a top-level list in a yamlDocument
+type yamlList struct {
+	// key is the line of the key
+	key int
+	// entries are the entries of the list, commented or not, sorted by line
+	entries []yamlListEntry
+	// end is the line following the last entry, or the key if there are no entries
+	end int
+	// regionEnd is the line following the region, which also includes the trailing comments and blank lines
+	regionEnd int
+}
+
+// yamlListEntry is an entry of a yamlList
+type yamlListEntry struct {
+	// start and end are the first line of the entry and the line following it
+	start, end int
+	// value is the value of a scalar entry
+	value     string
+	commented bool
+}
+
+// newYAMLDocument splits the content of path into lines
+func newYAMLDocument(path, content string) *yamlDocument {
+	d := &yamlDocument{path: path}
+	if content = strings.TrimSuffix(content, "\n"); content != "" {
+		d.lines = strings.Split(content, "\n")
+	}
+	return d
+}
+
+// String returns the content of the document
+func (d *yamlDocument) String() string {
+	if len(d.lines) == 0 {
+		return ""
+	}
+	return strings.Join(d.lines, "\n") + "\n"
+}
+
+// list finds the list of a top-level key, returning false if the key is not found
+func (d *yamlDocument) list(key string) (yamlList, bool, error) {
+	// Only the first document of the file is edited
+	var root yaml.Node
+	if err := yaml.NewDecoder(strings.NewReader(d.String())).Decode(&root); err != nil && err != io.EOF {
+		return yamlList{}, false, fmt.Errorf("unable to parse %s: %w", d.path, err)
+	}
+	if len(root.Content) == 0 || root.Content[0].Kind != yaml.MappingNode {
+		return yamlList{}, false, nil
+	}
+
+	mapping := root.Content[0].Content
+	for i := 0; i < len(mapping); i += 2 {
+		keyNode, value := mapping[i], mapping[i+1]
+		if keyNode.Value != key {
+			continue
+		}
+
+		// Keys without value are empty lists
+		isList := value.Kind == yaml.SequenceNode && value.Style&yaml.FlowStyle == 0
+		if !isList && (value.Kind != yaml.ScalarNode || value.Tag != "!!null" || value.Value != "") {
+			return yamlList{}, false, notAListError{d.path, key}
+		}
+
+		// The region ends at the next top-level key or document
+		l := yamlList{key: keyNode.Line - 1, end: keyNode.Line, regionEnd: len(d.lines)}
+		if i+2 < len(mapping) {
+			l.regionEnd = mapping[i+2].Line - 1
+		}
+		for j := l.key + 1; j < l.regionEnd; j++ {
+			if strings.HasPrefix(d.lines[j], "---") {
+				l.regionEnd = j
+				break
+			}
+		}
+
+		l.entries = d.entries(value, l)
+		for _, entry := range l.entries {
+			if entry.end > l.end {
+				l.end = entry.end
+			}
+		}
+		return l, true, nil
+	}
+	return yamlList{}, false, nil
+}
+
+// entries returns the entries of the list node in the region of l, along with the commented out entries
+func (d *yamlDocument) entries(list *yaml.Node, l yamlList) []yamlListEntry {
+	entries := make([]yamlListEntry, 0, len(list.Content))
+	covered := make(map[int]bool)
+	for _, item := range list.Content {
+		entry := yamlListEntry{start: item.Line - 1, end: lastLine(item)}
+		if item.Kind == yaml.ScalarNode {
+			entry.value = item.Value
+		}
+		for i := entry.start; i < entry.end; i++ {
+			covered[i] = true
+		}
+		entries = append(entries, entry)
+	}
+
+	// Commented out entries are comments which hold a single-entry list once uncommented
+	for i := l.key + 1; i < l.regionEnd; i++ {
+		if covered[i] {
+			continue
+		}
+		if value, isEntry := parseCommentedYAMLEntry(d.lines[i]); isEntry {
+			entries = append(entries, yamlListEntry{start: i, end: i + 1, value: value, commented: true})
+		}
+	}
+
+	sort.Slice(entries, func(i, j int) bool { return entries[i].start < entries[j].start })
+	return entries
+}
+
+// find returns an entry of a list, commented or not, and whether it was found
+func (l yamlList) find(value string) (yamlListEntry, bool) {
+	for _, entry := range l.entries {
+		if entry.value == value {
+			return entry, true
+		}
+	}
+	return yamlListEntry{}, false
+}
+
+// insert adds an entry to its list, adding the list if missing, and returns the inserted line or an empty string
+// if the entry already existed with the desired state
+func (d *yamlDocument) insert(entry file.YAMLEntry) (string, error) {
+	l, found, err := d.list(entry.Key)
+	if err != nil {
+		return "", err
+	}
+
+	// Append the list to the document if it does not exist
+	if !found {
+		line := renderYAMLEntry("", entry)
+		if len(d.lines) != 0 && strings.TrimSpace(d.lines[len(d.lines)-1]) != "" {
+			d.lines = append(d.lines, "")
+		}
+		d.lines = append(d.lines, entry.Key+":", line)
+		return line, nil
+	}
+
+	// Existing entries are only updated if they have to be toggled
+	if existing, exists := l.find(entry.Value); exists {
+		if !entry.Toggle || existing.commented == entry.Commented {
+			return "", nil
+		}
+		d.lines[existing.start] = renderYAMLEntry(indentation(d.lines[existing.start]), entry)
+		return d.lines[existing.start], nil
+	}
+
+	// Insert at the marker if present, otherwise after the last entry
+	at := l.end
+	if entry.Marker != (file.Marker{}) {
+		for i := l.key + 1; i < l.regionEnd; i++ {
+			if strings.TrimSpace(d.lines[i]) == entry.Marker.String() {
+				at = i
+				break
+			}
+		}
+	}
+	indent := ""
+	for _, existing := range l.entries {
+		if existing.start < at {
+			indent = indentation(d.lines[existing.start])
+		}
+	}
+	line := renderYAMLEntry(indent, entry)
+	d.lines = append(d.lines[:at], append([]string{line}, d.lines[at:]...)...)
+	return line, nil
+}
+
+// remove removes an entry from its list, commented or not, and returns the removed lines or an empty string
+// if the entry was not found
+func (d *yamlDocument) remove(entry file.YAMLEntry) (string, error) {
+	l, found, err := d.list(entry.Key)
+	if err != nil || !found {
+		return "", err
+	}
+
+	existing, exists := l.find(entry.Value)
+	if !exists {
+		return "", nil
+	}
+	removed := strings.Join(d.lines[existing.start:existing.end], "\n")
+	d.lines = append(d.lines[:existing.start], d.lines[existing.end:]...)
+	return removed, nil
+}
+
+// parseCommentedYAMLEntry returns the value of a commented out list entry, which is empty if it is not a scalar
+func parseCommentedYAMLEntry(line string) (string, bool) {
+	trimmed := strings.TrimSpace(line)
+	if !strings.HasPrefix(trimmed, "#") {
+		return "", false
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal([]byte(strings.TrimPrefix(trimmed, "#")), &doc); err != nil || len(doc.Content) == 0 {
+		return "", false
+	}
+	list := doc.Content[0]
+	if list.Kind != yaml.SequenceNode || list.Style&yaml.FlowStyle != 0 || len(list.Content) != 1 {
+		return "", false
+	}
+	if list.Content[0].Kind != yaml.ScalarNode {
+		return "", true
+	}
+	return list.Content[0].Value, true
+}
+
+// lastLine returns the number of the last line of a node, which is the index of the line following it
+func lastLine(n *yaml.Node) int {
+	last := n.Line
+	if n.Kind == yaml.ScalarNode && n.Style&(yaml.LiteralStyle|yaml.FoldedStyle) != 0 {
+		// Block scalars start at the line of their indicator
+		last += strings.Count(strings.TrimSuffix(n.Value, "\n"), "\n") + 1
+	}
+	for _, child := range n.Content {
+		if childLast := lastLine(child); childLast > last {
+			last = childLast
+		}
+	}
+	return last
+}
+
+// renderYAMLEntry returns the line of an entry
+func renderYAMLEntry(indent string, entry file.YAMLEntry) string {
+	if entry.Commented {
+		return indent + "#- " + entry.Value
+	}
+	return indent + "- " + entry.Value
+}
+
+// indentation returns the leading white space of a line
+func indentation(line string) string {
+	return line[:len(line)-len(strings.TrimLeft(line, " \t"))]
+}
@@ -0,0 +1,190 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machinery
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	"sigs.k8s.io/kubebuilder/pkg/model/file"
+)
+
+var _ = Describe("yamlDocument", func() {
+	var (
+		resourceMarker = file.NewMarkerFor("kustomization.yaml", "resource")
+		patchMarker    = file.NewMarkerFor("kustomization.yaml", "patch")
+
+		resource = file.YAMLEntry{Key: "resources", Value: "bases/b.yaml", Marker: resourceMarker}
+		patch    = file.YAMLEntry{Key: "patches", Value: "patches/b.yaml", Commented: true, Marker: patchMarker}
+	)
+
+	DescribeTable("should insert the entries",
+		func(content, expected string, entries ...file.YAMLEntry) {
+			doc := newYAMLDocument("kustomization.yaml", content)
+			for _, entry := range entries {
+				_, err := doc.insert(entry)
+				Expect(err).NotTo(HaveOccurred())
+			}
+			Expect(doc.String()).To(Equal(expected))
+		},
+		Entry("at their markers",
+			`resources:
+- bases/a.yaml
+# +kubebuilder:scaffold:resource
+
+patches:
+#- patches/a.yaml
+# +kubebuilder:scaffold:patch
+`,
+			`resources:
+- bases/a.yaml
+- bases/b.yaml
+# +kubebuilder:scaffold:resource
+
+patches:
+#- patches/a.yaml
+#- patches/b.yaml
+# +kubebuilder:scaffold:patch
+`,
+			resource, patch,
+		),
+		Entry("after the last entry of hand-edited lists without markers",
+			`# a comment
+resources:
+  - bases/a.yaml # the first resource
+  - ../other
+
+# patches are disabled
+patches:
+# - path: patches/a.yaml
+namePrefix: a-
+`,
+			`# a comment
+resources:
+  - bases/a.yaml # the first resource
+  - ../other
+  - bases/b.yaml
+
+# patches are disabled
+patches:
+# - path: patches/a.yaml
+#- patches/b.yaml
+namePrefix: a-
+`,
+			resource, patch,
+		),
+		Entry("after multi-line entries, ignoring the nested keys and the quotes",
+			`patches:
+- path: patches/a.yaml
+  target:
+    kind: A
+metadata:
+  resources:
+  - nested
+resources:
+- "bases/a.yaml"
+- 'bases/b.yaml'
+`,
+			`patches:
+- path: patches/a.yaml
+  target:
+    kind: A
+#- patches/b.yaml
+metadata:
+  resources:
+  - nested
+resources:
+- "bases/a.yaml"
+- 'bases/b.yaml'
+`,
+			resource, patch,
+		),
+		Entry("adding missing lists",
+			`namePrefix: a-
+`,
+			`namePrefix: a-
+
+resources:
+- bases/b.yaml
+`,
+			resource,
+		),
+		Entry("unless they exist in any state",
+			`resources:
+#- bases/b.yaml
+patches:
+- patches/b.yaml
+`,
+			`resources:
+#- bases/b.yaml
+patches:
+- patches/b.yaml
+`,
+			resource, patch,
+		),
+		Entry("toggling existing entries if requested",
+			`resources:
+  #- bases/b.yaml
+patches:
+- patches/b.yaml
+`,
+			`resources:
+  - bases/b.yaml
+patches:
+#- patches/b.yaml
+`,
+			file.YAMLEntry{Key: "resources", Value: "bases/b.yaml", Toggle: true},
+			file.YAMLEntry{Key: "patches", Value: "patches/b.yaml", Commented: true, Toggle: true},
+		),
+	)
+
+	It("should remove the entries in any state", func() {
+		doc := newYAMLDocument("kustomization.yaml", `resources:
+- bases/a.yaml
+- bases/b.yaml
+patches:
+#- patches/b.yaml
+`)
+		for _, entry := range []file.YAMLEntry{resource, patch, {Key: "missing", Value: "a"}} {
+			_, err := doc.remove(entry)
+			Expect(err).NotTo(HaveOccurred())
+		}
+		Expect(doc.String()).To(Equal(`resources:
+- bases/a.yaml
+patches:
+`))
+	})
+
+	It("should fail if the document cannot be parsed", func() {
+		doc := newYAMLDocument("kustomization.yaml", `resources:
+- bases/a.yaml
+ namePrefix: a-
+`)
+		_, err := doc.insert(resource)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("unable to parse kustomization.yaml"))
+	})
+
+	It("should fail if the value of the key is not a block list", func() {
+		doc := newYAMLDocument("kustomization.yaml", `resources: [bases/a.yaml]
+`)
+		_, err := doc.insert(resource)
+		Expect(err).To(HaveOccurred())
+		Expect(IsNotAListError(err)).To(BeTrue())
+	})
+})
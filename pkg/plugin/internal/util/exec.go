@@ -18,16 +18,24 @@ package util
 
 import (
 	"fmt"
-	"os"
 	"os/exec"
 	"strings"
+
+	"sigs.k8s.io/kubebuilder/pkg/plugin"
 )
 
-// RunCmd prints the provided message and command and then executes it binding stdout and stderr
-func RunCmd(msg, cmd string, args ...string) error {
+// RunCmd prints the provided message and command to the stdout of ctx and then executes it
+// in the working directory of ctx binding stdout and stderr
+// The command is recorded in the report of ctx, which may be nil
+func RunCmd(ctx plugin.Context, msg, cmd string, args ...string) error {
 	c := exec.Command(cmd, args...) //nolint:gosec
-	c.Stdout = os.Stdout
-	c.Stderr = os.Stderr
-	fmt.Println(msg + ":\n$ " + strings.Join(c.Args, " "))
+	c.Dir = ctx.WorkingDirectory
+	c.Stdout = ctx.Stdout
+	c.Stderr = ctx.Stderr
+	command := strings.Join(c.Args, " ")
+	fmt.Fprintln(ctx.Stdout, msg+":\n$ "+command)
+	if ctx.Report != nil {
+		ctx.Report.AddCommand(command)
+	}
 	return c.Run()
 }
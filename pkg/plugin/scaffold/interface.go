@@ -16,8 +16,14 @@ limitations under the License.
 
 package scaffold
 
+import (
+	"sigs.k8s.io/kubebuilder/pkg/model/file"
+)
+
 // Scaffolder interface creates files to set up a controller manager
 type Scaffolder interface {
+	// InjectFS sets the filesystem the scaffolded files are written to
+	InjectFS(file.Filesystem)
 	// Scaffold performs the scaffolding
 	Scaffold() error
 }
@@ -17,14 +17,13 @@ limitations under the License.
 package v2
 
 import (
-	"bufio"
 	"errors"
 	"fmt"
-	"io/ioutil"
 	"os"
 	"path/filepath"
 	"strings"
 
+	"github.com/spf13/afero"
 	"github.com/spf13/pflag"
 
 	"sigs.k8s.io/kubebuilder/internal/cmdutil"
@@ -40,6 +39,8 @@ import (
 
 type createAPIPlugin struct {
 	config *config.Config
+	// ctx is the runtime context provided by the CLI.
+	ctx plugin.Context
 
 	// pattern indicates that we should use a plugin to build according to a pattern
 	pattern string
@@ -64,7 +65,7 @@ var (
 	_ cmdutil.RunOptions = &createAPIPlugin{}
 )
 
-func (p createAPIPlugin) UpdateContext(ctx *plugin.Context) {
+func (p *createAPIPlugin) UpdateContext(ctx *plugin.Context) {
 	ctx.Description = `Scaffold a Kubernetes API by creating a Resource definition and / or a Controller.
 
 create resource will prompt the user for if it should scaffold the Resource and / or Controller.  To only
@@ -92,6 +93,8 @@ After the scaffold is written, api will run make on the project.
   make run
 	`,
 		ctx.CommandName)
+
+	p.ctx = *ctx
 }
 
 func (p *createAPIPlugin) BindFlags(fs *pflag.FlagSet) {
@@ -123,7 +126,7 @@ func (p *createAPIPlugin) InjectConfig(c *config.Config) {
 }
 
 func (p *createAPIPlugin) Run() error {
-	return cmdutil.Run(p)
+	return cmdutil.Run(p, p.ctx)
 }
 
 func (p *createAPIPlugin) Validate() error {
@@ -131,14 +134,21 @@ func (p *createAPIPlugin) Validate() error {
 		return err
 	}
 
-	reader := bufio.NewReader(os.Stdin)
+	var questions []plugin.Question
 	if !p.resourceFlag.Changed {
-		fmt.Println("Create Resource [y/n]")
-		p.doResource = util.YesNo(reader)
+		questions = append(questions, plugin.Question{Message: "Create Resource", Flag: "resource", Answer: &p.doResource})
 	}
 	if !p.controllerFlag.Changed {
-		fmt.Println("Create Controller [y/n]")
-		p.doController = util.YesNo(reader)
+		questions = append(questions,
+			plugin.Question{Message: "Create Controller", Flag: "controller", Answer: &p.doController})
+	}
+	if len(questions) != 0 {
+		if p.ctx.Prompter == nil {
+			return fmt.Errorf("unable to prompt, set the --resource and --controller flags")
+		}
+		if err := p.ctx.Prompter.Confirm(questions...); err != nil {
+			return err
+		}
 	}
 
 	// In case we want to scaffold a resource API we need to do some checks
@@ -160,7 +170,7 @@ func (p *createAPIPlugin) Validate() error {
 
 func (p *createAPIPlugin) GetScaffolder() (scaffold.Scaffolder, error) {
 	// Load the boilerplate
-	bp, err := ioutil.ReadFile(filepath.Join("hack", "boilerplate.go.txt")) // nolint:gosec
+	bp, err := afero.ReadFile(p.ctx.Filesystem, filepath.Join("hack", "boilerplate.go.txt"))
 	if err != nil {
 		return nil, fmt.Errorf("unable to load boilerplate: %v", err)
 	}
@@ -178,6 +188,8 @@ func (p *createAPIPlugin) GetScaffolder() (scaffold.Scaffolder, error) {
 
 	// Create the actual resource from the resource options
 	res := p.resource.NewResource(p.config, p.doResource)
+
+	fmt.Fprintln(p.ctx.Stdout, "Writing scaffold for you to edit...")
 	return scaffolds.NewAPIScaffolder(p.config, string(bp), res, p.doResource, p.doController, plugins), nil
 }
 
@@ -188,7 +200,7 @@ func (p *createAPIPlugin) PostScaffold() error {
 		// Default pattern
 	case "addon":
 		// Ensure that we are pinning sigs.k8s.io/kubebuilder-declarative-pattern version
-		err := util.RunCmd("Get controller runtime", "go", "get",
+		err := util.RunCmd(p.ctx, "Get controller runtime", "go", "get",
 			"sigs.k8s.io/kubebuilder-declarative-pattern@"+scaffolds.KbDeclarativePattern)
 		if err != nil {
 			return err
@@ -198,7 +210,7 @@ func (p *createAPIPlugin) PostScaffold() error {
 	}
 
 	if p.runMake {
-		return util.RunCmd("Running make", "make")
+		return util.RunCmd(p.ctx, "Running make", "make")
 	}
 	return nil
 }
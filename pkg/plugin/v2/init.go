@@ -18,7 +18,6 @@ package v2
 
 import (
 	"fmt"
-	"os"
 	"path/filepath"
 	"strings"
 
@@ -35,6 +34,8 @@ import (
 
 type initPlugin struct {
 	config *config.Config
+	// ctx is the runtime context provided by the CLI.
+	ctx plugin.Context
 	// For help text.
 	commandName string
 
@@ -42,6 +43,9 @@ type initPlugin struct {
 	license string
 	owner   string
 
+	// projectName is the name of the project directory, which is also stored in the config for v3 projects
+	projectName string
+
 	// flags
 	fetchDeps          bool
 	skipGoVersionCheck bool
@@ -73,6 +77,7 @@ project will prompt the user to run 'dep ensure' after writing the project files
 		ctx.CommandName)
 
 	p.commandName = ctx.CommandName
+	p.ctx = *ctx
 }
 
 func (p *initPlugin) BindFlags(fs *pflag.FlagSet) {
@@ -97,15 +102,11 @@ func (p *initPlugin) BindFlags(fs *pflag.FlagSet) {
 }
 
 func (p *initPlugin) InjectConfig(c *config.Config) {
-	// v3 project configs get a 'layout' value.
-	if c.IsV3() {
-		c.Layout = plugin.KeyFor(Plugin{})
-	}
 	p.config = c
 }
 
 func (p *initPlugin) Run() error {
-	return cmdutil.Run(p)
+	return cmdutil.Run(p, p.ctx)
 }
 
 func (p *initPlugin) Validate() error {
@@ -117,25 +118,21 @@ func (p *initPlugin) Validate() error {
 	}
 
 	// Check if the project name is a valid k8s namespace (DNS 1123 label).
-	dir, err := os.Getwd()
-	if err != nil {
-		return fmt.Errorf("error getting current directory: %v", err)
-	}
-	projectName := strings.ToLower(filepath.Base(dir))
+	p.projectName = strings.ToLower(filepath.Base(p.ctx.WorkingDirectory))
 	if p.config.IsV3() {
 		if p.config.ProjectName == "" {
-			p.config.ProjectName = projectName
+			p.config.ProjectName = p.projectName
 		} else {
-			projectName = p.config.ProjectName
+			p.projectName = p.config.ProjectName
 		}
 	}
-	if err := validation.IsDNS1123Label(projectName); err != nil {
-		return fmt.Errorf("project name (%s) is invalid: %v", projectName, err)
+	if err := validation.IsDNS1123Label(p.projectName); err != nil {
+		return fmt.Errorf("project name (%s) is invalid: %v", p.projectName, err)
 	}
 
 	// Try to guess repository if flag is not set.
 	if p.config.Repo == "" {
-		repoPath, err := util.FindCurrentRepo()
+		repoPath, err := util.FindCurrentRepo(p.ctx.WorkingDirectory)
 		if err != nil {
 			return fmt.Errorf("error finding current repository: %v", err)
 		}
@@ -146,33 +143,34 @@ func (p *initPlugin) Validate() error {
 }
 
 func (p *initPlugin) GetScaffolder() (scaffold.Scaffolder, error) {
-	return scaffolds.NewInitScaffolder(p.config, p.license, p.owner), nil
+	fmt.Fprintln(p.ctx.Stdout, "Writing scaffold for you to edit...")
+	return scaffolds.NewInitScaffolder(p.config, p.license, p.owner, p.projectName), nil
 }
 
 func (p *initPlugin) PostScaffold() error {
 	if !p.fetchDeps {
-		fmt.Println("Skipping fetching dependencies.")
+		fmt.Fprintln(p.ctx.Stdout, "Skipping fetching dependencies.")
 		return nil
 	}
 
 	// Ensure that we are pinning controller-runtime version
 	// xref: https://github.com/kubernetes-sigs/kubebuilder/issues/997
-	err := util.RunCmd("Get controller runtime", "go", "get",
+	err := util.RunCmd(p.ctx, "Get controller runtime", "go", "get",
 		"sigs.k8s.io/controller-runtime@"+scaffolds.ControllerRuntimeVersion)
 	if err != nil {
 		return err
 	}
 
-	err = util.RunCmd("Update go.mod", "go", "mod", "tidy")
+	err = util.RunCmd(p.ctx, "Update go.mod", "go", "mod", "tidy")
 	if err != nil {
 		return err
 	}
 
-	err = util.RunCmd("Running make", "make")
+	err = util.RunCmd(p.ctx, "Running make", "make")
 	if err != nil {
 		return err
 	}
 
-	fmt.Printf("Next: define a resource with:\n$ %s create api\n", p.commandName)
+	fmt.Fprintf(p.ctx.Stdout, "Next: define a resource with:\n$ %s create api\n", p.commandName)
 	return nil
 }
@@ -21,6 +21,7 @@ import (
 
 	"sigs.k8s.io/kubebuilder/pkg/model"
 	"sigs.k8s.io/kubebuilder/pkg/model/config"
+	"sigs.k8s.io/kubebuilder/pkg/model/file"
 	"sigs.k8s.io/kubebuilder/pkg/model/resource"
 	"sigs.k8s.io/kubebuilder/pkg/plugin/internal/machinery"
 	"sigs.k8s.io/kubebuilder/pkg/plugin/scaffold"
@@ -47,6 +48,9 @@ type apiScaffolder struct {
 	doResource bool
 	// doController indicates whether to scaffold controller files or not
 	doController bool
+
+	// fs is the filesystem that will be used by the scaffolder
+	fs file.Filesystem
 }
 
 // NewAPIScaffolder returns a new Scaffolder for API/controller creation operations
@@ -64,13 +68,17 @@ func NewAPIScaffolder(
 		plugins:      plugins,
 		doResource:   doResource,
 		doController: doController,
+		fs:           file.NewOSFilesystem(),
 	}
 }
 
+// InjectFS implements Scaffolder
+func (s *apiScaffolder) InjectFS(fs file.Filesystem) {
+	s.fs = fs
+}
+
 // Scaffold implements Scaffolder
 func (s *apiScaffolder) Scaffold() error {
-	fmt.Println("Writing scaffold for you to edit...")
-
 	switch {
 	case s.config.IsV2(), s.config.IsV3():
 		return s.scaffold()
@@ -88,10 +96,13 @@ func (s *apiScaffolder) newUniverse() *model.Universe {
 }
 
 func (s *apiScaffolder) scaffold() error {
+	// The controller of a tracked resource is only wired once
+	tracked, _ := s.config.GetResource(s.resource.GVK())
+
 	if s.doResource {
-		s.config.AddResource(s.resource.GVK())
+		s.config.UpdateResource(s.resource.Metadata())
 
-		if err := machinery.NewScaffold(s.plugins...).Execute(
+		if _, err := machinery.NewScaffold(s.fs, s.plugins...).Execute(
 			s.newUniverse(),
 			&templates.Types{},
 			&templates.Group{},
@@ -101,34 +112,41 @@ func (s *apiScaffolder) scaffold() error {
 			&crd.EnableWebhookPatch{},
 			&crd.EnableCAInjectionPatch{},
 		); err != nil {
-			return fmt.Errorf("error scaffolding APIs: %v", err)
+			return fmt.Errorf("error scaffolding APIs: %w", err)
 		}
 
-		if err := machinery.NewScaffold().Execute(
+		if _, err := machinery.NewScaffold(s.fs).Execute(
 			s.newUniverse(),
 			&crd.Kustomization{},
 			&crd.KustomizeConfig{},
 		); err != nil {
-			return fmt.Errorf("error scaffolding kustomization: %v", err)
+			return fmt.Errorf("error scaffolding kustomization: %w", err)
 		}
 
 	}
 
 	if s.doController {
-		if err := machinery.NewScaffold(s.plugins...).Execute(
+		// Only the controllers of the resources tracked in the project are recorded
+		if s.config.HasResource(s.resource.GVK()) {
+			gvk := s.resource.GVK()
+			gvk.Controller = true
+			s.config.UpdateResource(gvk)
+		}
+
+		if _, err := machinery.NewScaffold(s.fs, s.plugins...).Execute(
 			s.newUniverse(),
 			&controller.SuiteTest{},
 			&controller.Controller{},
 		); err != nil {
-			return fmt.Errorf("error scaffolding controller: %v", err)
+			return fmt.Errorf("error scaffolding controller: %w", err)
 		}
 	}
 
-	if err := machinery.NewScaffold(s.plugins...).Execute(
+	if _, err := machinery.NewScaffold(s.fs, s.plugins...).Execute(
 		s.newUniverse(),
-		&templates.MainUpdater{WireResource: s.doResource, WireController: s.doController},
+		&templates.MainUpdater{WireResource: s.doResource, WireController: s.doController && !tracked.Controller},
 	); err != nil {
-		return fmt.Errorf("error updating main.go: %v", err)
+		return fmt.Errorf("error updating main.go: %w", err)
 	}
 
 	return nil
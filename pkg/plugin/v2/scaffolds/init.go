@@ -18,11 +18,13 @@ package scaffolds
 
 import (
 	"fmt"
-	"io/ioutil"
 	"path/filepath"
 
+	"github.com/spf13/afero"
+
 	"sigs.k8s.io/kubebuilder/pkg/model"
 	"sigs.k8s.io/kubebuilder/pkg/model/config"
+	"sigs.k8s.io/kubebuilder/pkg/model/file"
 	"sigs.k8s.io/kubebuilder/pkg/plugin/internal/machinery"
 	"sigs.k8s.io/kubebuilder/pkg/plugin/scaffold"
 	"sigs.k8s.io/kubebuilder/pkg/plugin/v2/scaffolds/internal/templates"
@@ -51,18 +53,29 @@ type initScaffolder struct {
 	boilerplatePath string
 	license         string
 	owner           string
+	projectName     string
+
+	// fs is the filesystem that will be used by the scaffolder
+	fs file.Filesystem
 }
 
 // NewInitScaffolder returns a new Scaffolder for project initialization operations
-func NewInitScaffolder(config *config.Config, license, owner string) scaffold.Scaffolder {
+func NewInitScaffolder(config *config.Config, license, owner, projectName string) scaffold.Scaffolder {
 	return &initScaffolder{
 		config:          config,
 		boilerplatePath: filepath.Join("hack", "boilerplate.go.txt"),
 		license:         license,
 		owner:           owner,
+		projectName:     projectName,
+		fs:              file.NewOSFilesystem(),
 	}
 }
 
+// InjectFS implements Scaffolder
+func (s *initScaffolder) InjectFS(fs file.Filesystem) {
+	s.fs = fs
+}
+
 func (s *initScaffolder) newUniverse(boilerplate string) *model.Universe {
 	return model.NewUniverse(
 		model.WithConfig(s.config),
@@ -72,8 +85,6 @@ func (s *initScaffolder) newUniverse(boilerplate string) *model.Universe {
 
 // Scaffold implements Scaffolder
 func (s *initScaffolder) Scaffold() error {
-	fmt.Println("Writing scaffold for you to edit...")
-
 	switch {
 	case s.config.IsV2(), s.config.IsV3():
 		return s.scaffold()
@@ -87,19 +98,19 @@ func (s *initScaffolder) scaffold() error {
 	bpFile.Path = s.boilerplatePath
 	bpFile.License = s.license
 	bpFile.Owner = s.owner
-	if err := machinery.NewScaffold().Execute(
+	if _, err := machinery.NewScaffold(s.fs).Execute(
 		s.newUniverse(""),
 		bpFile,
 	); err != nil {
 		return err
 	}
 
-	boilerplate, err := ioutil.ReadFile(s.boilerplatePath) //nolint:gosec
+	boilerplate, err := afero.ReadFile(s.fs.FS, s.boilerplatePath)
 	if err != nil {
 		return err
 	}
 
-	return machinery.NewScaffold().Execute(
+	_, err = machinery.NewScaffold(s.fs).Execute(
 		s.newUniverse(string(boilerplate)),
 		&templates.GitIgnore{},
 		&templates.AuthProxyRole{},
@@ -117,7 +128,7 @@ func (s *initScaffolder) scaffold() error {
 			KustomizeVersion:       KustomizeVersion,
 		},
 		&templates.Dockerfile{},
-		&templates.Kustomize{},
+		&templates.Kustomize{ProjectNameMixin: file.ProjectNameMixin{ProjectName: s.projectName}},
 		&templates.ManagerWebhookPatch{},
 		&templates.ManagerRoleBinding{},
 		&templates.LeaderElectionRole{},
@@ -134,4 +145,5 @@ func (s *initScaffolder) scaffold() error {
 		&certmanager.Kustomization{},
 		&certmanager.KustomizeConfig{},
 	)
+	return err
 }
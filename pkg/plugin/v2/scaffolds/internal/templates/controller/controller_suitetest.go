@@ -24,7 +24,7 @@ import (
 )
 
 var _ file.Template = &SuiteTest{}
-var _ file.Inserter = &SuiteTest{}
+var _ file.GoInserter = &SuiteTest{}
 
 // SuiteTest scaffolds the suite_test.go file to setup the controller test
 type SuiteTest struct {
@@ -77,6 +77,14 @@ func (f *SuiteTest) GetMarkers() []file.Marker {
 	}
 }
 
+// GetInsertionPoints implements file.GoInserter
+func (f *SuiteTest) GetInsertionPoints() map[file.Marker]file.InsertionPoint {
+	return map[file.Marker]file.InsertionPoint{
+		file.NewMarkerFor(f.Path, importMarker):    {Imports: true},
+		file.NewMarkerFor(f.Path, addSchemeMarker): {Func: "BeforeSuite", Before: "k8sClient, err = client.New("},
+	}
+}
+
 const (
 	apiImportCodeFragment = `%s "%s"
 `
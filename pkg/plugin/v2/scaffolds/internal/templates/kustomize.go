@@ -17,9 +17,7 @@ limitations under the License.
 package templates
 
 import (
-	"os"
 	"path/filepath"
-	"strings"
 
 	"sigs.k8s.io/kubebuilder/pkg/model/file"
 )
@@ -42,15 +40,6 @@ func (f *Kustomize) SetTemplateDefaults() error {
 
 	f.IfExistsAction = file.Error
 
-	if f.ProjectName == "" {
-		// Use directory name as project name, which will be empty if the project version is < v3.
-		dir, err := os.Getwd()
-		if err != nil {
-			return err
-		}
-		f.ProjectName = strings.ToLower(filepath.Base(dir))
-	}
-
 	return nil
 }
 
@@ -68,7 +68,7 @@ func (f *Main) GetFuncMap() template.FuncMap {
 	return fm
 }
 
-var _ file.Inserter = &MainUpdater{}
+var _ file.GoInserter = &MainUpdater{}
 
 // MainUpdater updates main.go to run Controllers
 type MainUpdater struct { //nolint:maligned
@@ -105,6 +105,15 @@ func (f *MainUpdater) GetMarkers() []file.Marker {
 	}
 }
 
+// GetInsertionPoints implements file.GoInserter
+func (f *MainUpdater) GetInsertionPoints() map[file.Marker]file.InsertionPoint {
+	return map[file.Marker]file.InsertionPoint{
+		file.NewMarkerFor(defaultMainPath, importMarker):    {Imports: true},
+		file.NewMarkerFor(defaultMainPath, addSchemeMarker): {Func: "init"},
+		file.NewMarkerFor(defaultMainPath, setupMarker):     {Func: "main", Before: `setupLog.Info("starting manager")`},
+	}
+}
+
 const (
 	apiImportCodeFragment = `%s "%s"
 `
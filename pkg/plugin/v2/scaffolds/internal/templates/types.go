@@ -17,7 +17,6 @@ limitations under the License.
 package templates
 
 import (
-	"fmt"
 	"path/filepath"
 
 	"sigs.k8s.io/kubebuilder/pkg/model/file"
@@ -43,7 +42,6 @@ func (f *Types) SetTemplateDefaults() error {
 		}
 	}
 	f.Path = f.Resource.Replacer().Replace(f.Path)
-	fmt.Println(f.Path)
 
 	f.TemplateBody = typesTemplate
 
@@ -21,6 +21,7 @@ import (
 
 	"sigs.k8s.io/kubebuilder/pkg/model"
 	"sigs.k8s.io/kubebuilder/pkg/model/config"
+	"sigs.k8s.io/kubebuilder/pkg/model/file"
 	"sigs.k8s.io/kubebuilder/pkg/model/resource"
 	"sigs.k8s.io/kubebuilder/pkg/plugin/internal/machinery"
 	"sigs.k8s.io/kubebuilder/pkg/plugin/scaffold"
@@ -28,6 +29,10 @@ import (
 	"sigs.k8s.io/kubebuilder/pkg/plugin/v2/scaffolds/internal/templates/webhook"
 )
 
+// conversionStep is the manual step left after scaffolding a conversion webhook
+const conversionStep = "implement the conversion.Hub and conversion.Convertible interfaces for your CRD types, " +
+	"the webhook server has been set up for you"
+
 var _ scaffold.Scaffolder = &webhookScaffolder{}
 
 type webhookScaffolder struct {
@@ -37,6 +42,9 @@ type webhookScaffolder struct {
 
 	// v2
 	defaulting, validation, conversion bool
+
+	// fs is the filesystem that will be used by the scaffolder
+	fs file.Filesystem
 }
 
 // NewWebhookScaffolder returns a new Scaffolder for v2 webhook creation operations
@@ -55,13 +63,17 @@ func NewWebhookScaffolder(
 		defaulting:  defaulting,
 		validation:  validation,
 		conversion:  conversion,
+		fs:          file.NewOSFilesystem(),
 	}
 }
 
+// InjectFS implements Scaffolder
+func (s *webhookScaffolder) InjectFS(fs file.Filesystem) {
+	s.fs = fs
+}
+
 // Scaffold implements Scaffolder
 func (s *webhookScaffolder) Scaffold() error {
-	fmt.Println("Writing scaffold for you to edit...")
-
 	switch {
 	case s.config.IsV2(), s.config.IsV3():
 		return s.scaffold()
@@ -79,18 +91,22 @@ func (s *webhookScaffolder) newUniverse() *model.Universe {
 }
 
 func (s *webhookScaffolder) scaffold() error {
-	if s.conversion {
-		fmt.Println(`Webhook server has been set up for you.
-You need to implement the conversion.Hub and conversion.Convertible interfaces for your CRD types.`)
-	}
+	// The webhooks of a resource are only wired once
+	tracked, _ := s.config.GetResource(s.resource.GVK())
+	gvk := s.resource.GVK()
+	gvk.Webhooks = &config.Webhooks{Defaulting: s.defaulting, Validation: s.validation, Conversion: s.conversion}
+	s.config.UpdateResource(gvk)
 
-	if err := machinery.NewScaffold().Execute(
+	if _, err := machinery.NewScaffold(s.fs).Execute(
 		s.newUniverse(),
 		&webhook.Webhook{Defaulting: s.defaulting, Validating: s.validation},
-		&templates.MainUpdater{WireWebhook: true},
+		&templates.MainUpdater{WireWebhook: tracked.Webhooks == nil},
 	); err != nil {
 		return err
 	}
 
+	if s.conversion && s.fs.Report != nil {
+		s.fs.Report.AddManualStep(conversionStep)
+	}
 	return nil
 }
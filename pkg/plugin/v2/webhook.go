@@ -18,9 +18,10 @@ package v2
 
 import (
 	"fmt"
-	"io/ioutil"
 	"path/filepath"
+	"strings"
 
+	"github.com/spf13/afero"
 	"github.com/spf13/pflag"
 
 	"sigs.k8s.io/kubebuilder/internal/cmdutil"
@@ -33,6 +34,8 @@ import (
 
 type createWebhookPlugin struct {
 	config *config.Config
+	// ctx is the runtime context provided by the CLI.
+	ctx plugin.Context
 	// For help text.
 	commandName string
 
@@ -61,6 +64,7 @@ validating and (or) conversion webhooks.
 		ctx.CommandName, ctx.CommandName)
 
 	p.commandName = ctx.CommandName
+	p.ctx = *ctx
 }
 
 func (p *createWebhookPlugin) BindFlags(fs *pflag.FlagSet) {
@@ -83,7 +87,7 @@ func (p *createWebhookPlugin) InjectConfig(c *config.Config) {
 }
 
 func (p *createWebhookPlugin) Run() error {
-	return cmdutil.Run(p)
+	return cmdutil.Run(p, p.ctx)
 }
 
 func (p *createWebhookPlugin) Validate() error {
@@ -97,23 +101,44 @@ func (p *createWebhookPlugin) Validate() error {
 	}
 
 	// check if resource exist to create webhook
-	if !p.config.HasResource(p.resource.GVK()) {
+	res, found := p.config.GetResource(p.resource.GVK())
+	if !found {
 		return fmt.Errorf("%s create webhook requires an api with the group,"+
 			" kind and version provided", p.commandName)
 	}
 
+	// check that the requested webhooks were not scaffolded yet, which is only tracked in v3+ projects
+	if res.Webhooks != nil {
+		var duplicates []string
+		if p.defaulting && res.Webhooks.Defaulting {
+			duplicates = append(duplicates, "defaulting")
+		}
+		if p.validation && res.Webhooks.Validation {
+			duplicates = append(duplicates, "validation")
+		}
+		if p.conversion && res.Webhooks.Conversion {
+			duplicates = append(duplicates, "conversion")
+		}
+		if len(duplicates) != 0 {
+			return fmt.Errorf("%s webhooks already exist for %s/%s, kind %s", strings.Join(duplicates, " and "),
+				res.Group, res.Version, res.Kind)
+		}
+	}
+
 	return nil
 }
 
 func (p *createWebhookPlugin) GetScaffolder() (scaffold.Scaffolder, error) {
 	// Load the boilerplate
-	bp, err := ioutil.ReadFile(filepath.Join("hack", "boilerplate.go.txt")) // nolint:gosec
+	bp, err := afero.ReadFile(p.ctx.Filesystem, filepath.Join("hack", "boilerplate.go.txt"))
 	if err != nil {
 		return nil, fmt.Errorf("unable to load boilerplate: %v", err)
 	}
 
 	// Create the actual resource from the resource options
 	res := p.resource.NewResource(p.config, false)
+
+	fmt.Fprintln(p.ctx.Stdout, "Writing scaffold for you to edit...")
 	return scaffolds.NewWebhookScaffolder(p.config, string(bp), res, p.defaulting, p.validation, p.conversion), nil
 }
 
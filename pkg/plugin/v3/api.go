@@ -17,19 +17,19 @@ limitations under the License.
 package v3
 
 import (
-	"bufio"
 	"errors"
 	"fmt"
-	"io/ioutil"
 	"os"
 	"path/filepath"
 	"strings"
 
+	"github.com/spf13/afero"
 	"github.com/spf13/pflag"
 
 	"sigs.k8s.io/kubebuilder/internal/cmdutil"
 	"sigs.k8s.io/kubebuilder/pkg/model"
 	"sigs.k8s.io/kubebuilder/pkg/model/config"
+	"sigs.k8s.io/kubebuilder/pkg/model/file"
 	"sigs.k8s.io/kubebuilder/pkg/model/resource"
 	"sigs.k8s.io/kubebuilder/pkg/plugin"
 	"sigs.k8s.io/kubebuilder/pkg/plugin/internal/util"
@@ -48,6 +48,8 @@ const DefaultMainPath = "main.go"
 
 type createAPIPlugin struct {
 	config *config.Config
+	// ctx is the runtime context provided by the CLI.
+	ctx plugin.Context
 
 	// pattern indicates that we should use a plugin to build according to a pattern
 	pattern string
@@ -60,8 +62,11 @@ type createAPIPlugin struct {
 	doResource     bool
 	doController   bool
 
-	// force indicates that the resource should be created even if it already exists
-	force bool
+	// force are the values of the --force flag
+	force []string
+	// forcedClasses are the classes of files overwritten if they already exist, the resource is created
+	// even if it already exists if there is any
+	forcedClasses []file.Class
 
 	// runMake indicates whether to run make or not after scaffolding APIs
 	runMake bool
@@ -70,9 +75,10 @@ type createAPIPlugin struct {
 var (
 	_ plugin.CreateAPI   = &createAPIPlugin{}
 	_ cmdutil.RunOptions = &createAPIPlugin{}
+	_ cmdutil.HasForce   = &createAPIPlugin{}
 )
 
-func (p createAPIPlugin) UpdateContext(ctx *plugin.Context) {
+func (p *createAPIPlugin) UpdateContext(ctx *plugin.Context) {
 	ctx.Description = `Scaffold a Kubernetes API by creating a Resource definition and / or a Controller.
 
 create resource will prompt the user for if it should scaffold the Resource and / or Controller.  To only
@@ -100,6 +106,8 @@ After the scaffold is written, api will run make on the project.
   make run
 	`,
 		ctx.CommandName)
+
+	p.ctx = *ctx
 }
 
 func (p *createAPIPlugin) BindFlags(fs *pflag.FlagSet) {
@@ -118,8 +126,7 @@ func (p *createAPIPlugin) BindFlags(fs *pflag.FlagSet) {
 			"generates an API following an extension pattern (addon)")
 	}
 
-	fs.BoolVar(&p.force, "force", false,
-		"attempt to create resource even if it already exists")
+	bindForceFlag(fs, &p.force, "attempt to create resource even if it already exists")
 	p.resource = &resource.Options{}
 	fs.StringVar(&p.resource.Kind, "kind", "", "resource Kind")
 	fs.StringVar(&p.resource.Group, "group", "", "resource Group")
@@ -132,7 +139,7 @@ func (p *createAPIPlugin) InjectConfig(c *config.Config) {
 }
 
 func (p *createAPIPlugin) Run() error {
-	return cmdutil.Run(p)
+	return cmdutil.Run(p, p.ctx)
 }
 
 func (p *createAPIPlugin) Validate() error {
@@ -140,31 +147,43 @@ func (p *createAPIPlugin) Validate() error {
 		return err
 	}
 
+	var err error
+	if p.forcedClasses, err = parseForce(p.force); err != nil {
+		return err
+	}
+
 	if p.resource.Group == "" && p.config.Domain == "" {
 		return fmt.Errorf("can not have group and domain both empty")
 	}
 
 	// check if main.go is present in the root directory
-	if _, err := os.Stat(DefaultMainPath); os.IsNotExist(err) {
+	if _, err := p.ctx.Filesystem.Stat(DefaultMainPath); os.IsNotExist(err) {
 		return fmt.Errorf("%s file should present in the root directory", DefaultMainPath)
 	}
 
 	// TODO: re-evaluate whether y/n input still makes sense. We should probably always
 	// scaffold the resource and controller.
-	reader := bufio.NewReader(os.Stdin)
+	var questions []plugin.Question
 	if !p.resourceFlag.Changed {
-		fmt.Println("Create Resource [y/n]")
-		p.doResource = util.YesNo(reader)
+		questions = append(questions, plugin.Question{Message: "Create Resource", Flag: "resource", Answer: &p.doResource})
 	}
 	if !p.controllerFlag.Changed {
-		fmt.Println("Create Controller [y/n]")
-		p.doController = util.YesNo(reader)
+		questions = append(questions,
+			plugin.Question{Message: "Create Controller", Flag: "controller", Answer: &p.doController})
+	}
+	if len(questions) != 0 {
+		if p.ctx.Prompter == nil {
+			return fmt.Errorf("unable to prompt, set the --resource and --controller flags")
+		}
+		if err := p.ctx.Prompter.Confirm(questions...); err != nil {
+			return err
+		}
 	}
 
 	// In case we want to scaffold a resource API we need to do some checks
 	if p.doResource {
 		// Check that resource doesn't exist or flag force was set
-		if !p.force && p.config.HasResource(p.resource.GVK()) {
+		if len(p.forcedClasses) == 0 && p.config.HasResource(p.resource.GVK()) {
 			return errors.New("API resource already exists")
 		}
 
@@ -180,7 +199,7 @@ func (p *createAPIPlugin) Validate() error {
 
 func (p *createAPIPlugin) GetScaffolder() (scaffold.Scaffolder, error) {
 	// Load the boilerplate
-	bp, err := ioutil.ReadFile(filepath.Join("hack", "boilerplate.go.txt")) // nolint:gosec
+	bp, err := afero.ReadFile(p.ctx.Filesystem, filepath.Join("hack", "boilerplate.go.txt"))
 	if err != nil {
 		return nil, fmt.Errorf("unable to load boilerplate: %v", err)
 	}
@@ -198,9 +217,16 @@ func (p *createAPIPlugin) GetScaffolder() (scaffold.Scaffolder, error) {
 
 	// Create the actual resource from the resource options
 	res := p.resource.NewResource(p.config, p.doResource)
+
+	fmt.Fprintln(p.ctx.Stdout, "Writing scaffold for you to edit...")
 	return scaffolds.NewAPIScaffolder(p.config, string(bp), res, p.doResource, p.doController, plugins), nil
 }
 
+// GetForce implements cmdutil.HasForce
+func (p *createAPIPlugin) GetForce() []file.Class {
+	return p.forcedClasses
+}
+
 func (p *createAPIPlugin) PostScaffold() error {
 	// Load the requested plugins
 	switch strings.ToLower(p.pattern) {
@@ -209,7 +235,7 @@ func (p *createAPIPlugin) PostScaffold() error {
 	case "addon":
 		// Ensure that we are pinning sigs.k8s.io/kubebuilder-declarative-pattern version
 		// TODO: either find a better way to inject this version (ex. tools.go).
-		err := util.RunCmd("Get kubebuilder-declarative-pattern dependency", "go", "get",
+		err := util.RunCmd(p.ctx, "Get kubebuilder-declarative-pattern dependency", "go", "get",
 			"sigs.k8s.io/kubebuilder-declarative-pattern@"+KbDeclarativePatternVersion)
 		if err != nil {
 			return err
@@ -219,7 +245,7 @@ func (p *createAPIPlugin) PostScaffold() error {
 	}
 
 	if p.runMake {
-		return util.RunCmd("Running make", "make")
+		return util.RunCmd(p.ctx, "Running make", "make")
 	}
 	return nil
 }
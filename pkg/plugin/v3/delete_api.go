@@ -0,0 +1,127 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v3
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/pflag"
+
+	"sigs.k8s.io/kubebuilder/internal/cmdutil"
+	"sigs.k8s.io/kubebuilder/pkg/model/config"
+	"sigs.k8s.io/kubebuilder/pkg/model/resource"
+	"sigs.k8s.io/kubebuilder/pkg/plugin"
+	"sigs.k8s.io/kubebuilder/pkg/plugin/scaffold"
+	"sigs.k8s.io/kubebuilder/pkg/plugin/v3/scaffolds"
+)
+
+// errNotConfirmed is returned when the user does not confirm a deletion
+var errNotConfirmed = errors.New("deletion was not confirmed")
+
+type deleteAPIPlugin struct {
+	config *config.Config
+	// ctx is the runtime context provided by the CLI.
+	ctx plugin.Context
+
+	resource *resource.Options
+}
+
+var (
+	_ plugin.DeleteAPI   = &deleteAPIPlugin{}
+	_ cmdutil.RunOptions = &deleteAPIPlugin{}
+)
+
+func (p *deleteAPIPlugin) UpdateContext(ctx *plugin.Context) {
+	ctx.Description = `Delete a Kubernetes API scaffolded by create api, along with its controller and webhooks.
+
+Removes the types, sample, RBAC roles, CRD patches, controller and webhook files of the resource, and
+the code inserted for it in main.go and config/crd/kustomization.yaml. The group version package is
+removed too if no other resource uses it. The resource is removed from the PROJECT file.
+`
+	ctx.Examples = fmt.Sprintf(`  # Delete the frigates API with Group: ship, Version: v1beta1 and Kind: Frigate
+  %s delete api --group ship --version v1beta1 --kind Frigate
+
+  # Regenerate code and manifests without the deleted API
+  make generate manifests
+`, ctx.CommandName)
+
+	p.ctx = *ctx
+}
+
+func (p *deleteAPIPlugin) BindFlags(fs *pflag.FlagSet) {
+	p.resource = &resource.Options{}
+	fs.StringVar(&p.resource.Group, "group", "", "resource Group")
+	fs.StringVar(&p.resource.Version, "version", "", "resource Version")
+	fs.StringVar(&p.resource.Kind, "kind", "", "resource Kind")
+	fs.StringVar(&p.resource.Plural, "resource", "", "resource Resource")
+}
+
+func (p *deleteAPIPlugin) InjectConfig(c *config.Config) {
+	p.config = c
+}
+
+func (p *deleteAPIPlugin) Run() error {
+	return cmdutil.Run(p, p.ctx)
+}
+
+func (p *deleteAPIPlugin) Validate() error {
+	if err := p.resource.Validate(); err != nil {
+		return err
+	}
+
+	res, found := p.config.GetResource(p.resource.GVK())
+	if !found {
+		return fmt.Errorf("API resource %s/%s, kind %s is not tracked in the project",
+			p.resource.Group, p.resource.Version, p.resource.Kind)
+	}
+	if p.resource.Plural == "" {
+		p.resource.Plural = res.Plural
+	}
+
+	return confirmDeletion(p.ctx, fmt.Sprintf("Delete API %s/%s, kind %s, with its controller and webhooks",
+		res.Group, res.Version, res.Kind))
+}
+
+func (p *deleteAPIPlugin) GetScaffolder() (scaffold.Scaffolder, error) {
+	// Create the actual resource from the resource options
+	res := p.resource.NewResource(p.config, true)
+	return scaffolds.NewDeleteAPIScaffolder(p.config, res), nil
+}
+
+func (p *deleteAPIPlugin) PostScaffold() error {
+	return nil
+}
+
+// confirmDeletion asks the user to confirm the deletion described by message, unless in dry run
+func confirmDeletion(ctx plugin.Context, message string) error {
+	if ctx.DryRun {
+		return nil
+	}
+	if ctx.Prompter == nil {
+		return fmt.Errorf("unable to prompt, set the --yes flag")
+	}
+
+	confirmed := false
+	if err := ctx.Prompter.Confirm(plugin.Question{Message: message, Flag: "yes", Answer: &confirmed}); err != nil {
+		return err
+	}
+	if !confirmed {
+		return errNotConfirmed
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v3
+
+import (
+	"fmt"
+
+	"github.com/spf13/pflag"
+
+	"sigs.k8s.io/kubebuilder/internal/cmdutil"
+	"sigs.k8s.io/kubebuilder/pkg/model/config"
+	"sigs.k8s.io/kubebuilder/pkg/model/resource"
+	"sigs.k8s.io/kubebuilder/pkg/plugin"
+	"sigs.k8s.io/kubebuilder/pkg/plugin/scaffold"
+	"sigs.k8s.io/kubebuilder/pkg/plugin/v3/scaffolds"
+)
+
+type deleteWebhookPlugin struct {
+	config *config.Config
+	// ctx is the runtime context provided by the CLI.
+	ctx plugin.Context
+
+	resource *resource.Options
+}
+
+var (
+	_ plugin.DeleteWebhook = &deleteWebhookPlugin{}
+	_ cmdutil.RunOptions   = &deleteWebhookPlugin{}
+)
+
+func (p *deleteWebhookPlugin) UpdateContext(ctx *plugin.Context) {
+	ctx.Description = `Delete the webhooks scaffolded by create webhook for an API resource.
+
+Removes the webhook file of the resource and its setup in main.go. The webhooks are removed from
+the resource in the PROJECT file.
+`
+	ctx.Examples = fmt.Sprintf(`  # Delete the webhooks of the CRD of group crew, version v1 and kind FirstMate
+  %s delete webhook --group crew --version v1 --kind FirstMate
+`, ctx.CommandName)
+
+	p.ctx = *ctx
+}
+
+func (p *deleteWebhookPlugin) BindFlags(fs *pflag.FlagSet) {
+	p.resource = &resource.Options{}
+	fs.StringVar(&p.resource.Group, "group", "", "resource Group")
+	fs.StringVar(&p.resource.Version, "version", "", "resource Version")
+	fs.StringVar(&p.resource.Kind, "kind", "", "resource Kind")
+}
+
+func (p *deleteWebhookPlugin) InjectConfig(c *config.Config) {
+	p.config = c
+}
+
+func (p *deleteWebhookPlugin) Run() error {
+	return cmdutil.Run(p, p.ctx)
+}
+
+func (p *deleteWebhookPlugin) Validate() error {
+	if err := p.resource.Validate(); err != nil {
+		return err
+	}
+
+	res, found := p.config.GetResource(p.resource.GVK())
+	if !found {
+		return fmt.Errorf("API resource %s/%s, kind %s is not tracked in the project",
+			p.resource.Group, p.resource.Version, p.resource.Kind)
+	}
+	if res.Webhooks == nil {
+		return fmt.Errorf("no webhooks are tracked for %s/%s, kind %s", res.Group, res.Version, res.Kind)
+	}
+
+	return confirmDeletion(p.ctx, fmt.Sprintf("Delete the webhooks of %s/%s, kind %s",
+		res.Group, res.Version, res.Kind))
+}
+
+func (p *deleteWebhookPlugin) GetScaffolder() (scaffold.Scaffolder, error) {
+	// Create the actual resource from the resource options
+	res := p.resource.NewResource(p.config, true)
+	return scaffolds.NewDeleteWebhookScaffolder(p.config, res), nil
+}
+
+func (p *deleteWebhookPlugin) PostScaffold() error {
+	return nil
+}
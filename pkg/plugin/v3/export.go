@@ -0,0 +1,94 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v3
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/pflag"
+
+	"sigs.k8s.io/kubebuilder/internal/cmdutil"
+	"sigs.k8s.io/kubebuilder/pkg/model/config"
+	"sigs.k8s.io/kubebuilder/pkg/plugin"
+	"sigs.k8s.io/kubebuilder/pkg/plugin/scaffold"
+	"sigs.k8s.io/kubebuilder/pkg/plugin/v3/scaffolds"
+)
+
+type exportTemplatesPlugin struct {
+	config *config.Config
+	// ctx is the runtime context provided by the CLI.
+	ctx plugin.Context
+
+	force bool
+}
+
+var (
+	_ plugin.GenericSubcommand = &exportTemplatesPlugin{}
+	_ cmdutil.RunOptions       = &exportTemplatesPlugin{}
+)
+
+func (p *exportTemplatesPlugin) UpdateContext(ctx *plugin.Context) {
+	ctx.Description = `Export the default templates to the template directory, as a starting point to override them.
+
+Each template is exported to a file named after its type, e.g. templates.Dockerfile.tmpl, which overrides
+the template of every file it scaffolds. A template can also be overridden for a single file with a file named
+after its output path, e.g. config/manager/manager.yaml.tmpl.
+
+Overrides are rendered with the same fields and functions as the default templates, e.g. the template of the
+webhooks scaffolds its defaulting and validating parts under {{ if .Defaulting }} and {{ if .Validating }}.
+`
+	ctx.Examples = fmt.Sprintf(`  # Export the default templates to the template directory of the project
+  %s alpha export-templates
+
+  # Export the default templates to another directory, overwriting the ones already exported
+  %s alpha export-templates --template-dir=../templates --force
+`, ctx.CommandName, ctx.CommandName)
+
+	p.ctx = *ctx
+}
+
+func (p *exportTemplatesPlugin) BindFlags(fs *pflag.FlagSet) {
+	fs.BoolVar(&p.force, "force", false, "overwrite the templates which were already exported")
+}
+
+func (p *exportTemplatesPlugin) InjectConfig(c *config.Config) {
+	p.config = c
+}
+
+func (p *exportTemplatesPlugin) Run() error {
+	// The templates are written to the template directory instead of the project
+	ctx := p.ctx
+	ctx.Filesystem = p.ctx.Templates
+	return cmdutil.Run(p, ctx)
+}
+
+func (p *exportTemplatesPlugin) Validate() error {
+	if p.ctx.Templates == nil {
+		return errors.New("no template directory to export the templates to")
+	}
+	return nil
+}
+
+func (p *exportTemplatesPlugin) GetScaffolder() (scaffold.Scaffolder, error) {
+	return scaffolds.NewExportScaffolder(p.config, p.force), nil
+}
+
+func (p *exportTemplatesPlugin) PostScaffold() error {
+	fmt.Fprintln(p.ctx.Stdout, "Next: edit the exported templates, the files scaffolded from now on use them")
+	return nil
+}
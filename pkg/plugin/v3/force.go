@@ -0,0 +1,58 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v3
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/pflag"
+
+	"sigs.k8s.io/kubebuilder/pkg/model/file"
+)
+
+// forceFlag is the flag that overwrites the files that already exist, by class
+const forceFlag = "force"
+
+// bindForceFlag binds --force to values. The flag takes the classes of files to overwrite if they already exist,
+// and if set without value every class is overwritten. The classes must be set with "=", as a separate value
+// is taken as a positional argument.
+func bindForceFlag(fs *pflag.FlagSet, values *[]string, usage string) {
+	names := make([]string, 0, len(file.Classes))
+	for _, class := range file.Classes {
+		names = append(names, string(class))
+	}
+
+	fs.StringSliceVar(values, forceFlag, nil, fmt.Sprintf("%s. Overwrite the existing files of the given classes (%s), "+
+		"e.g. --%s=%s, instead of failing, or every file if set without value",
+		usage, strings.Join(names, ", "), forceFlag, names[0]))
+	fs.Lookup(forceFlag).NoOptDefVal = file.AllClasses
+}
+
+// parseForce returns the classes of files to overwrite set by --force.
+// It also accepts the boolean values of the flag before it took classes.
+func parseForce(values []string) ([]file.Class, error) {
+	if len(values) == 1 {
+		switch values[0] {
+		case "true":
+			return file.Classes, nil
+		case "false":
+			return nil, nil
+		}
+	}
+	return file.ParseClasses(values)
+}
@@ -18,7 +18,6 @@ package v3
 
 import (
 	"fmt"
-	"os"
 	"path/filepath"
 	"strings"
 
@@ -35,6 +34,8 @@ import (
 
 type initPlugin struct {
 	config *config.Config
+	// ctx is the runtime context provided by the CLI.
+	ctx plugin.Context
 	// For help text.
 	commandName string
 
@@ -73,6 +74,7 @@ project will prompt the user to run 'dep ensure' after writing the project files
 		ctx.CommandName)
 
 	p.commandName = ctx.CommandName
+	p.ctx = *ctx
 }
 
 func (p *initPlugin) BindFlags(fs *pflag.FlagSet) {
@@ -95,12 +97,11 @@ func (p *initPlugin) BindFlags(fs *pflag.FlagSet) {
 }
 
 func (p *initPlugin) InjectConfig(c *config.Config) {
-	c.Layout = plugin.KeyFor(Plugin{})
 	p.config = c
 }
 
 func (p *initPlugin) Run() error {
-	return cmdutil.Run(p)
+	return cmdutil.Run(p, p.ctx)
 }
 
 func (p *initPlugin) Validate() error {
@@ -113,11 +114,7 @@ func (p *initPlugin) Validate() error {
 
 	// Check if the project name is a valid k8s namespace (DNS 1123 label).
 	if p.config.ProjectName == "" {
-		dir, err := os.Getwd()
-		if err != nil {
-			return fmt.Errorf("error getting current directory: %v", err)
-		}
-		p.config.ProjectName = strings.ToLower(filepath.Base(dir))
+		p.config.ProjectName = strings.ToLower(filepath.Base(p.ctx.WorkingDirectory))
 	}
 	if err := validation.IsDNS1123Label(p.config.ProjectName); err != nil {
 		return fmt.Errorf("project name (%s) is invalid: %v", p.config.ProjectName, err)
@@ -125,7 +122,7 @@ func (p *initPlugin) Validate() error {
 
 	// Try to guess repository if flag is not set.
 	if p.config.Repo == "" {
-		repoPath, err := util.FindCurrentRepo()
+		repoPath, err := util.FindCurrentRepo(p.ctx.WorkingDirectory)
 		if err != nil {
 			return fmt.Errorf("error finding current repository: %v", err)
 		}
@@ -136,34 +133,35 @@ func (p *initPlugin) Validate() error {
 }
 
 func (p *initPlugin) GetScaffolder() (scaffold.Scaffolder, error) {
+	fmt.Fprintln(p.ctx.Stdout, "Writing scaffold for you to edit...")
 	return scaffolds.NewInitScaffolder(p.config, p.license, p.owner), nil
 }
 
 func (p *initPlugin) PostScaffold() error {
 	if !p.fetchDeps {
-		fmt.Println("Skipping fetching dependencies.")
+		fmt.Fprintln(p.ctx.Stdout, "Skipping fetching dependencies.")
 		return nil
 	}
 
 	// Ensure that we are pinning controller-runtime version
 	// xref: https://github.com/kubernetes-sigs/kubebuilder/issues/997
-	err := util.RunCmd("Get controller runtime", "go", "get",
+	err := util.RunCmd(p.ctx, "Get controller runtime", "go", "get",
 		"sigs.k8s.io/controller-runtime@"+scaffolds.ControllerRuntimeVersion)
 	if err != nil {
 		return err
 	}
 
-	err = util.RunCmd("Update go.mod", "go", "mod", "tidy")
+	err = util.RunCmd(p.ctx, "Update go.mod", "go", "mod", "tidy")
 	if err != nil {
 		return err
 	}
 
 	// TODO: make this conditional with a '--make' flag, like in 'create api'.
-	err = util.RunCmd("Running make", "make")
+	err = util.RunCmd(p.ctx, "Running make", "make")
 	if err != nil {
 		return err
 	}
 
-	fmt.Printf("Next: define a resource with:\n$ %s create api\n", p.commandName)
+	fmt.Fprintf(p.ctx.Stdout, "Next: define a resource with:\n$ %s create api\n", p.commandName)
 	return nil
 }
@@ -0,0 +1,104 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v3
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/pflag"
+
+	"sigs.k8s.io/kubebuilder/internal/cmdutil"
+	"sigs.k8s.io/kubebuilder/pkg/internal/validation"
+	"sigs.k8s.io/kubebuilder/pkg/model/config"
+	"sigs.k8s.io/kubebuilder/pkg/plugin"
+	"sigs.k8s.io/kubebuilder/pkg/plugin/scaffold"
+	"sigs.k8s.io/kubebuilder/pkg/plugin/v3/scaffolds"
+)
+
+type migratePlugin struct {
+	config *config.Config
+	// ctx is the runtime context provided by the CLI.
+	ctx plugin.Context
+}
+
+var (
+	_ plugin.GenericSubcommand = &migratePlugin{}
+	_ cmdutil.RunOptions       = &migratePlugin{}
+)
+
+func (p *migratePlugin) UpdateContext(ctx *plugin.Context) {
+	ctx.Description = `Migrate a project with version 2 to version 3-alpha.
+
+Updates the following:
+- the PROJECT file with the version, the layout, the project name and the metadata of the resources
+- the files whose scaffold changed in version 3-alpha: .dockerignore, .gitignore, Dockerfile, go.mod,
+  Makefile, the manager and leader election manifests, the webhook patches of the CRDs, the controllers
+  and their test suites
+
+Fragments of these files which were customized are left untouched and reported as manual steps.
+`
+	ctx.Examples = fmt.Sprintf(`  # Migrate the project in the current directory
+  %s alpha migrate
+
+  # Print the changes the migration would make
+  %s alpha migrate --dry-run
+`, ctx.CommandName, ctx.CommandName)
+
+	p.ctx = *ctx
+}
+
+func (p *migratePlugin) BindFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&p.config.ProjectName, "project-name", "", "name of this project, "+
+		"defaults to the name of the current working directory")
+}
+
+func (p *migratePlugin) InjectConfig(c *config.Config) {
+	p.config = c
+}
+
+func (p *migratePlugin) Run() error {
+	return cmdutil.Run(p, p.ctx)
+}
+
+func (p *migratePlugin) Validate() error {
+	if !p.config.IsV2() {
+		return fmt.Errorf("only projects with version %q can be migrated, found version %q",
+			config.Version2, p.config.Version)
+	}
+
+	// Check if the project name is a valid k8s namespace (DNS 1123 label).
+	if p.config.ProjectName == "" {
+		p.config.ProjectName = strings.ToLower(filepath.Base(p.ctx.WorkingDirectory))
+	}
+	if err := validation.IsDNS1123Label(p.config.ProjectName); err != nil {
+		return fmt.Errorf("project name (%s) is invalid: %v", p.config.ProjectName, err)
+	}
+
+	return nil
+}
+
+func (p *migratePlugin) GetScaffolder() (scaffold.Scaffolder, error) {
+	return scaffolds.NewMigrateScaffolder(p.config, plugin.KeyFor(Plugin{})), nil
+}
+
+func (p *migratePlugin) PostScaffold() error {
+	fmt.Fprintf(p.ctx.Stdout, "Next: update the dependencies and check the project builds with:\n"+
+		"$ go mod tidy && make\n")
+	return nil
+}
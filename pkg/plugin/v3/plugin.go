@@ -17,6 +17,8 @@ limitations under the License.
 package v3
 
 import (
+	"fmt"
+
 	"sigs.k8s.io/kubebuilder/pkg/model/config"
 	"sigs.k8s.io/kubebuilder/pkg/plugin"
 )
@@ -33,6 +35,10 @@ var (
 	_ plugin.InitPluginGetter          = Plugin{}
 	_ plugin.CreateAPIPluginGetter     = Plugin{}
 	_ plugin.CreateWebhookPluginGetter = Plugin{}
+	_ plugin.DeleteAPIPluginGetter     = Plugin{}
+	_ plugin.DeleteWebhookPluginGetter = Plugin{}
+	_ plugin.EditPluginGetter          = Plugin{}
+	_ plugin.ExtraCommandsGetter       = Plugin{}
 )
 
 // Plugin defines the plugins operations for the v3+ plugin versions.
@@ -40,6 +46,12 @@ type Plugin struct {
 	initPlugin
 	createAPIPlugin
 	createWebhookPlugin
+	deleteAPIPlugin
+	deleteWebhookPlugin
+	editPlugin
+	migratePlugin
+	regeneratePlugin
+	exportTemplatesPlugin
 }
 
 // Name returns the name of the plugin for the v3+ which is in this case `go.kubebuilder.io`
@@ -61,3 +73,38 @@ func (p Plugin) GetCreateAPIPlugin() plugin.CreateAPI { return &p.createAPIPlugi
 
 // GetCreateWebhookPlugin will return the plugin for v3+ which is responsible for scaffold webhooks for the project
 func (p Plugin) GetCreateWebhookPlugin() plugin.CreateWebhook { return &p.createWebhookPlugin }
+
+// GetDeleteAPIPlugin will return the plugin for v3+ which is responsible for deleting apis
+func (p Plugin) GetDeleteAPIPlugin() plugin.DeleteAPI { return &p.deleteAPIPlugin }
+
+// GetDeleteWebhookPlugin will return the plugin for v3+ which is responsible for deleting webhooks
+func (p Plugin) GetDeleteWebhookPlugin() plugin.DeleteWebhook { return &p.deleteWebhookPlugin }
+
+// GetEditPlugin will return the plugin for v3+ which is responsible for editing the scaffold of the project
+func (p Plugin) GetEditPlugin() plugin.Edit { return &p.editPlugin }
+
+// GetExtraCommands will return the commands contributed by the plugin for v3+: migrating version 2 projects,
+// regenerating the scaffold of projects and exporting the default templates
+func (p Plugin) GetExtraCommands() []plugin.ExtraCommand {
+	return []plugin.ExtraCommand{
+		{
+			Name:            "migrate",
+			Short:           fmt.Sprintf("Migrate a project to project version %q", config.Version3Alpha),
+			Alpha:           true,
+			ProjectVersions: []string{config.Version2},
+			Subcommand:      &p.migratePlugin,
+		},
+		{
+			Name:       "regenerate",
+			Short:      "Regenerate the scaffold of the project with the current templates",
+			Alpha:      true,
+			Subcommand: &p.regeneratePlugin,
+		},
+		{
+			Name:       "export-templates",
+			Short:      "Export the default templates to the template directory",
+			Alpha:      true,
+			Subcommand: &p.exportTemplatesPlugin,
+		},
+	}
+}
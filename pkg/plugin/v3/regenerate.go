@@ -0,0 +1,95 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v3
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/spf13/afero"
+	"github.com/spf13/pflag"
+
+	"sigs.k8s.io/kubebuilder/internal/cmdutil"
+	"sigs.k8s.io/kubebuilder/pkg/model/config"
+	"sigs.k8s.io/kubebuilder/pkg/plugin"
+	"sigs.k8s.io/kubebuilder/pkg/plugin/scaffold"
+	"sigs.k8s.io/kubebuilder/pkg/plugin/v3/scaffolds"
+)
+
+type regeneratePlugin struct {
+	config *config.Config
+	// ctx is the runtime context provided by the CLI.
+	ctx plugin.Context
+}
+
+var (
+	_ plugin.GenericSubcommand = &regeneratePlugin{}
+	_ cmdutil.RunOptions       = &regeneratePlugin{}
+)
+
+func (p *regeneratePlugin) UpdateContext(ctx *plugin.Context) {
+	ctx.Description = fmt.Sprintf(`Regenerate the scaffold of the project with the current templates.
+
+The project and the resources tracked in PROJECT are scaffolded from scratch, and the changes of the new
+scaffold are merged into the project files:
+- with a three-way merge against the scaffold stored in %[1]s by init, create api, create webhook
+  and the previous regeneration, so that the changes made to the files since then are kept
+- with a two-way merge if the file has no stored scaffold, the lines added to either version are kept
+
+The chunks changed both in the project and in the scaffold are written with conflict markers and reported
+as manual steps. Files removed from the project since the previous regeneration are not restored.
+Commit %[1]s along with the project so that the next regeneration can merge from it.
+`, scaffolds.BaselineDir)
+	ctx.Examples = fmt.Sprintf(`  # Regenerate the project in the current directory
+  %s alpha regenerate
+
+  # Print the files the regeneration would change
+  %s alpha regenerate --dry-run
+`, ctx.CommandName, ctx.CommandName)
+
+	p.ctx = *ctx
+}
+
+func (p *regeneratePlugin) BindFlags(*pflag.FlagSet) {}
+
+func (p *regeneratePlugin) InjectConfig(c *config.Config) {
+	p.config = c
+}
+
+func (p *regeneratePlugin) Run() error {
+	return cmdutil.Run(p, p.ctx)
+}
+
+func (p *regeneratePlugin) Validate() error {
+	return nil
+}
+
+func (p *regeneratePlugin) GetScaffolder() (scaffold.Scaffolder, error) {
+	// Load the boilerplate
+	bp, err := afero.ReadFile(p.ctx.Filesystem, filepath.Join("hack", "boilerplate.go.txt"))
+	if err != nil {
+		return nil, fmt.Errorf("unable to load boilerplate: %v", err)
+	}
+
+	return scaffolds.NewRegenerateScaffolder(p.config, string(bp)), nil
+}
+
+func (p *regeneratePlugin) PostScaffold() error {
+	fmt.Fprintf(p.ctx.Stdout, "Next: review the merged files, then regenerate the manifests and check the "+
+		"project builds with:\n$ make generate manifests && make\n")
+	return nil
+}
@@ -21,6 +21,7 @@ import (
 
 	"sigs.k8s.io/kubebuilder/pkg/model"
 	"sigs.k8s.io/kubebuilder/pkg/model/config"
+	"sigs.k8s.io/kubebuilder/pkg/model/file"
 	"sigs.k8s.io/kubebuilder/pkg/model/resource"
 	"sigs.k8s.io/kubebuilder/pkg/plugin/internal/machinery"
 	"sigs.k8s.io/kubebuilder/pkg/plugin/scaffold"
@@ -46,6 +47,9 @@ type apiScaffolder struct {
 	doResource bool
 	// doController indicates whether to scaffold controller files or not
 	doController bool
+
+	// fs is the filesystem that will be used by the scaffolder
+	fs file.Filesystem
 }
 
 // NewAPIScaffolder returns a new Scaffolder for API/controller creation operations
@@ -63,13 +67,29 @@ func NewAPIScaffolder(
 		plugins:      plugins,
 		doResource:   doResource,
 		doController: doController,
+		fs:           file.NewOSFilesystem(),
 	}
 }
 
+// InjectFS implements Scaffolder
+func (s *apiScaffolder) InjectFS(fs file.Filesystem) {
+	s.fs = fs
+}
+
 // Scaffold implements Scaffolder
 func (s *apiScaffolder) Scaffold() error {
-	fmt.Println("Writing scaffold for you to edit...")
-	return s.scaffold()
+	replay := *s
+	replay.config = copyConfig(s.config)
+
+	changes, err := s.scaffold()
+	if err != nil {
+		return err
+	}
+	return updateBaseline(s.fs, changes, func(fs file.Filesystem) error {
+		replay.fs = fs
+		_, err := replay.scaffold()
+		return err
+	})
 }
 
 func (s *apiScaffolder) newUniverse() *model.Universe {
@@ -81,11 +101,16 @@ func (s *apiScaffolder) newUniverse() *model.Universe {
 }
 
 // TODO: re-use universe created by s.newUniverse() if possible.
-func (s *apiScaffolder) scaffold() error {
+func (s *apiScaffolder) scaffold() ([]file.Change, error) {
+	// The controller of a tracked resource is only wired once
+	tracked, _ := s.config.GetResource(s.resource.GVK())
+
+	var changes []file.Change
+
 	if s.doResource {
-		s.config.AddResource(s.resource.GVK())
+		s.config.UpdateResource(s.resource.Metadata())
 
-		if err := machinery.NewScaffold(s.plugins...).Execute(
+		executed, err := machinery.NewScaffold(s.fs, s.plugins...).Execute(
 			s.newUniverse(),
 			&api.Types{},
 			&api.Group{},
@@ -94,36 +119,48 @@ func (s *apiScaffolder) scaffold() error {
 			&rbac.CRDViewerRole{},
 			&crd.EnableWebhookPatch{},
 			&crd.EnableCAInjectionPatch{},
-		); err != nil {
-			return fmt.Errorf("error scaffolding APIs: %v", err)
+		)
+		if err != nil {
+			return nil, fmt.Errorf("error scaffolding APIs: %w", err)
 		}
+		changes = append(changes, executed...)
 
-		if err := machinery.NewScaffold().Execute(
+		executed, err = machinery.NewScaffold(s.fs).Execute(
 			s.newUniverse(),
 			&crd.Kustomization{},
 			&crd.KustomizeConfig{},
-		); err != nil {
-			return fmt.Errorf("error scaffolding kustomization: %v", err)
+		)
+		if err != nil {
+			return nil, fmt.Errorf("error scaffolding kustomization: %w", err)
 		}
-
+		changes = append(changes, executed...)
 	}
 
 	if s.doController {
-		if err := machinery.NewScaffold(s.plugins...).Execute(
+		// Only the controllers of the resources tracked in the project are recorded
+		if s.config.HasResource(s.resource.GVK()) {
+			gvk := s.resource.GVK()
+			gvk.Controller = true
+			s.config.UpdateResource(gvk)
+		}
+
+		executed, err := machinery.NewScaffold(s.fs, s.plugins...).Execute(
 			s.newUniverse(),
 			&controller.SuiteTest{},
 			&controller.Controller{},
-		); err != nil {
-			return fmt.Errorf("error scaffolding controller: %v", err)
+		)
+		if err != nil {
+			return nil, fmt.Errorf("error scaffolding controller: %w", err)
 		}
+		changes = append(changes, executed...)
 	}
 
-	if err := machinery.NewScaffold(s.plugins...).Execute(
+	executed, err := machinery.NewScaffold(s.fs, s.plugins...).Execute(
 		s.newUniverse(),
-		&templates.MainUpdater{WireResource: s.doResource, WireController: s.doController},
-	); err != nil {
-		return fmt.Errorf("error updating main.go: %v", err)
+		&templates.MainUpdater{WireResource: s.doResource, WireController: s.doController && !tracked.Controller},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error updating main.go: %w", err)
 	}
-
-	return nil
+	return append(changes, executed...), nil
 }
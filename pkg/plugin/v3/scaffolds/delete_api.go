@@ -0,0 +1,162 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaffolds
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"sigs.k8s.io/kubebuilder/pkg/model"
+	"sigs.k8s.io/kubebuilder/pkg/model/config"
+	"sigs.k8s.io/kubebuilder/pkg/model/file"
+	"sigs.k8s.io/kubebuilder/pkg/model/resource"
+	"sigs.k8s.io/kubebuilder/pkg/plugin/internal/machinery"
+	"sigs.k8s.io/kubebuilder/pkg/plugin/scaffold"
+	"sigs.k8s.io/kubebuilder/pkg/plugin/v3/scaffolds/internal/templates"
+	"sigs.k8s.io/kubebuilder/pkg/plugin/v3/scaffolds/internal/templates/config/api"
+	"sigs.k8s.io/kubebuilder/pkg/plugin/v3/scaffolds/internal/templates/config/controller"
+	"sigs.k8s.io/kubebuilder/pkg/plugin/v3/scaffolds/internal/templates/config/crd"
+	"sigs.k8s.io/kubebuilder/pkg/plugin/v3/scaffolds/internal/templates/config/rbac"
+	"sigs.k8s.io/kubebuilder/pkg/plugin/v3/scaffolds/internal/templates/config/samples"
+)
+
+// regenerateStep is the manual step left after deleting scaffolded code, as the files generated
+// by controller-gen still refer to it
+const regenerateStep = "run `make generate manifests` to regenerate the deepcopy functions, CRDs, RBAC and webhook manifests"
+
+var _ scaffold.Scaffolder = &deleteAPIScaffolder{}
+
+// deleteAPIScaffolder contains configuration for removing the scaffolding of the Go type
+// representing the API and the controller that implements the behavior for the API.
+type deleteAPIScaffolder struct {
+	config   *config.Config
+	resource *resource.Resource
+
+	// fs is the filesystem that will be used by the scaffolder
+	fs file.Filesystem
+}
+
+// NewDeleteAPIScaffolder returns a new Scaffolder for API/controller deletion operations
+func NewDeleteAPIScaffolder(config *config.Config, res *resource.Resource) scaffold.Scaffolder {
+	return &deleteAPIScaffolder{
+		config:   config,
+		resource: res,
+		fs:       file.NewOSFilesystem(),
+	}
+}
+
+// InjectFS implements Scaffolder
+func (s *deleteAPIScaffolder) InjectFS(fs file.Filesystem) {
+	s.fs = fs
+}
+
+// Scaffold implements Scaffolder
+func (s *deleteAPIScaffolder) Scaffold() error {
+	replay := *s
+	replay.config = copyConfig(s.config)
+
+	changes, err := s.scaffold()
+	if err != nil {
+		return err
+	}
+	return updateBaseline(s.fs, changes, func(fs file.Filesystem) error {
+		replay.fs = fs
+		_, err := replay.scaffold()
+		return err
+	})
+}
+
+func (s *deleteAPIScaffolder) newUniverse() *model.Universe {
+	return model.NewUniverse(
+		model.WithConfig(s.config),
+		model.WithResource(s.resource),
+	)
+}
+
+func (s *deleteAPIScaffolder) scaffold() ([]file.Change, error) {
+	s.config.RemoveResource(s.resource.GVK())
+
+	// The group version package, its scheme and the controllers package are shared with other resources
+	apiInUse, controllersInUse := false, false
+	for _, r := range s.config.Resources {
+		if r.Group == s.resource.Group && r.Version == s.resource.Version {
+			apiInUse = true
+		}
+		if r.Controller && (!s.config.MultiGroup || r.Group == s.resource.Group) {
+			controllersInUse = true
+		}
+	}
+
+	// Files and fragments that were not scaffolded, like the controller or the webhook, are ignored
+	types := &api.Types{}
+	builders := []file.Builder{
+		types,
+		&samples.CRDSample{},
+		&rbac.CRDEditorRole{},
+		&rbac.CRDViewerRole{},
+		&crd.EnableWebhookPatch{},
+		&crd.EnableCAInjectionPatch{},
+		&crd.Kustomization{},
+		&controller.Controller{},
+		&api.Webhook{},
+		&templates.MainUpdater{
+			WireController:   true,
+			WireWebhook:      true,
+			APIInUse:         apiInUse,
+			ControllersInUse: controllersInUse,
+		},
+	}
+	if !apiInUse {
+		builders = append(builders, &api.Group{}, &controller.SuiteTest{})
+	}
+	changes, err := machinery.NewScaffold(s.fs).Delete(s.newUniverse(), builders...)
+	if err != nil {
+		return nil, fmt.Errorf("error deleting API: %w", err)
+	}
+
+	// The files generated for the deleted type are removed too, as they do not compile or apply without it
+	if _, err := machinery.NewScaffold(s.fs).Delete(
+		s.newUniverse(),
+		newGeneratedFile(filepath.Dir(types.Path), "zz_generated.deepcopy.go"),
+		newGeneratedFile("config", "crd", "bases", fmt.Sprintf("%s_%s.yaml", s.resource.Domain, s.resource.Plural)),
+	); err != nil {
+		return nil, fmt.Errorf("error deleting generated files: %w", err)
+	}
+
+	if s.fs.Report != nil {
+		s.fs.Report.AddManualStep(regenerateStep)
+	}
+
+	return changes, nil
+}
+
+var _ file.Builder = &generatedFile{}
+
+// generatedFile is a file generated by controller-gen from the scaffolded ones
+type generatedFile struct {
+	file.PathMixin
+}
+
+// newGeneratedFile returns the generatedFile with the path joining elem
+func newGeneratedFile(elem ...string) *generatedFile {
+	return &generatedFile{file.PathMixin{Path: filepath.Join(elem...)}}
+}
+
+// GetIfExistsAction implements file.Builder
+func (*generatedFile) GetIfExistsAction() file.IfExistsAction {
+	return file.Overwrite
+}
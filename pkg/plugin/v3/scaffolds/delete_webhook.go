@@ -0,0 +1,98 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaffolds
+
+import (
+	"fmt"
+
+	"sigs.k8s.io/kubebuilder/pkg/model"
+	"sigs.k8s.io/kubebuilder/pkg/model/config"
+	"sigs.k8s.io/kubebuilder/pkg/model/file"
+	"sigs.k8s.io/kubebuilder/pkg/model/resource"
+	"sigs.k8s.io/kubebuilder/pkg/plugin/internal/machinery"
+	"sigs.k8s.io/kubebuilder/pkg/plugin/scaffold"
+	"sigs.k8s.io/kubebuilder/pkg/plugin/v3/scaffolds/internal/templates"
+	"sigs.k8s.io/kubebuilder/pkg/plugin/v3/scaffolds/internal/templates/config/api"
+)
+
+var _ scaffold.Scaffolder = &deleteWebhookScaffolder{}
+
+// deleteWebhookScaffolder contains configuration for removing the scaffolding of the webhooks of a resource.
+type deleteWebhookScaffolder struct {
+	config   *config.Config
+	resource *resource.Resource
+
+	// fs is the filesystem that will be used by the scaffolder
+	fs file.Filesystem
+}
+
+// NewDeleteWebhookScaffolder returns a new Scaffolder for webhook deletion operations
+func NewDeleteWebhookScaffolder(config *config.Config, res *resource.Resource) scaffold.Scaffolder {
+	return &deleteWebhookScaffolder{
+		config:   config,
+		resource: res,
+		fs:       file.NewOSFilesystem(),
+	}
+}
+
+// InjectFS implements Scaffolder
+func (s *deleteWebhookScaffolder) InjectFS(fs file.Filesystem) {
+	s.fs = fs
+}
+
+// Scaffold implements Scaffolder
+func (s *deleteWebhookScaffolder) Scaffold() error {
+	replay := *s
+	replay.config = copyConfig(s.config)
+
+	changes, err := s.scaffold()
+	if err != nil {
+		return err
+	}
+	return updateBaseline(s.fs, changes, func(fs file.Filesystem) error {
+		replay.fs = fs
+		_, err := replay.scaffold()
+		return err
+	})
+}
+
+func (s *deleteWebhookScaffolder) newUniverse() *model.Universe {
+	return model.NewUniverse(
+		model.WithConfig(s.config),
+		model.WithResource(s.resource),
+	)
+}
+
+func (s *deleteWebhookScaffolder) scaffold() ([]file.Change, error) {
+	s.config.RemoveWebhooks(s.resource.GVK())
+
+	// The cert-manager and CA injection patches are shared by every webhook of the project, so they are kept
+	changes, err := machinery.NewScaffold(s.fs).Delete(
+		s.newUniverse(),
+		&api.Webhook{},
+		&templates.MainUpdater{WireWebhook: true, APIInUse: true},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error deleting webhook: %w", err)
+	}
+
+	if s.fs.Report != nil {
+		s.fs.Report.AddManualStep(regenerateStep)
+	}
+
+	return changes, nil
+}
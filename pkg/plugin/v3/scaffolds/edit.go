@@ -18,10 +18,12 @@ package scaffolds
 
 import (
 	"fmt"
-	"io/ioutil"
 	"strings"
 
+	"github.com/spf13/afero"
+
 	"sigs.k8s.io/kubebuilder/pkg/model/config"
+	"sigs.k8s.io/kubebuilder/pkg/model/file"
 	"sigs.k8s.io/kubebuilder/pkg/plugin/scaffold"
 )
 
@@ -30,6 +32,9 @@ var _ scaffold.Scaffolder = &editScaffolder{}
 type editScaffolder struct {
 	config     *config.Config
 	multigroup bool
+
+	// fs is the filesystem that will be used by the scaffolder
+	fs file.Filesystem
 }
 
 // NewEditScaffolder returns a new Scaffolder for configuration edit operations
@@ -37,14 +42,20 @@ func NewEditScaffolder(config *config.Config, multigroup bool) scaffold.Scaffold
 	return &editScaffolder{
 		config:     config,
 		multigroup: multigroup,
+		fs:         file.NewOSFilesystem(),
 	}
 }
 
+// InjectFS implements Scaffolder
+func (s *editScaffolder) InjectFS(fs file.Filesystem) {
+	s.fs = fs
+}
+
 // Scaffold implements Scaffolder
 func (s *editScaffolder) Scaffold() error {
 	s.config.MultiGroup = s.multigroup
 	filename := "Dockerfile"
-	bs, err := ioutil.ReadFile(filename)
+	bs, err := afero.ReadFile(s.fs.FS, filename)
 	if err != nil {
 		return err
 	}
@@ -70,7 +81,13 @@ func (s *editScaffolder) Scaffold() error {
 	}
 	// false positive
 	// nolint:gosec
-	return ioutil.WriteFile(filename, []byte(str), 0644)
+	if err := afero.WriteFile(s.fs.FS, filename, []byte(str), 0644); err != nil {
+		return err
+	}
+	if s.fs.Report != nil {
+		s.fs.Report.Add(file.Change{Path: filename, Operation: file.Overwritten})
+	}
+	return nil
 }
 
 func ensureExistAndReplace(input, match, replace string) (string, error) {
@@ -0,0 +1,157 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaffolds
+
+import (
+	"github.com/spf13/afero"
+
+	"sigs.k8s.io/kubebuilder/pkg/model"
+	"sigs.k8s.io/kubebuilder/pkg/model/config"
+	"sigs.k8s.io/kubebuilder/pkg/model/file"
+	"sigs.k8s.io/kubebuilder/pkg/model/resource"
+	"sigs.k8s.io/kubebuilder/pkg/plugin/internal/machinery"
+	"sigs.k8s.io/kubebuilder/pkg/plugin/scaffold"
+	"sigs.k8s.io/kubebuilder/pkg/plugin/v3/scaffolds/internal/templates"
+	"sigs.k8s.io/kubebuilder/pkg/plugin/v3/scaffolds/internal/templates/config/api"
+	"sigs.k8s.io/kubebuilder/pkg/plugin/v3/scaffolds/internal/templates/config/certmanager"
+	"sigs.k8s.io/kubebuilder/pkg/plugin/v3/scaffolds/internal/templates/config/controller"
+	"sigs.k8s.io/kubebuilder/pkg/plugin/v3/scaffolds/internal/templates/config/crd"
+	"sigs.k8s.io/kubebuilder/pkg/plugin/v3/scaffolds/internal/templates/config/kdefault"
+	"sigs.k8s.io/kubebuilder/pkg/plugin/v3/scaffolds/internal/templates/config/manager"
+	"sigs.k8s.io/kubebuilder/pkg/plugin/v3/scaffolds/internal/templates/config/prometheus"
+	"sigs.k8s.io/kubebuilder/pkg/plugin/v3/scaffolds/internal/templates/config/rbac"
+	"sigs.k8s.io/kubebuilder/pkg/plugin/v3/scaffolds/internal/templates/config/samples"
+	"sigs.k8s.io/kubebuilder/pkg/plugin/v3/scaffolds/internal/templates/config/webhook"
+)
+
+var _ scaffold.Scaffolder = &exportScaffolder{}
+
+type exportScaffolder struct {
+	config *config.Config
+	force  bool
+
+	// fs is the filesystem that will be used by the scaffolder, rooted at the template directory
+	fs file.Filesystem
+}
+
+// NewExportScaffolder returns a new Scaffolder for exporting the default template bodies, so that they can be
+// used as a starting point to override them. Existing templates are only overwritten if force is set.
+func NewExportScaffolder(config *config.Config, force bool) scaffold.Scaffolder {
+	return &exportScaffolder{
+		config: config,
+		force:  force,
+		fs:     file.NewOSFilesystem(),
+	}
+}
+
+// InjectFS implements Scaffolder
+func (s *exportScaffolder) InjectFS(fs file.Filesystem) {
+	s.fs = fs
+}
+
+// Scaffold implements Scaffolder
+func (s *exportScaffolder) Scaffold() error {
+	// The template bodies do not depend on the resource, a placeholder is used to set their defaults
+	opts := resource.Options{Group: "group", Version: "v1", Kind: "Kind", Namespaced: true}
+	universe := model.NewUniverse(
+		model.WithConfig(s.config),
+		model.WithResource(opts.NewResource(s.config, true)),
+	)
+
+	for _, t := range defaultTemplates() {
+		universe.InjectInto(t)
+		if err := t.SetTemplateDefaults(); err != nil {
+			return file.NewSetTemplateDefaultsError(err)
+		}
+
+		path := machinery.TemplateKey(t) + machinery.TemplateOverrideExt
+		exists, err := afero.Exists(s.fs.FS, path)
+		if err != nil {
+			return err
+		}
+		operation := file.Created
+		if exists {
+			operation = file.Overwritten
+			if !s.force {
+				operation = file.Skipped
+			}
+		}
+
+		if operation != file.Skipped {
+			// false positive
+			// nolint:gosec
+			if err := afero.WriteFile(s.fs.FS, path, []byte(t.GetBody()), 0644); err != nil {
+				return err
+			}
+		}
+		if s.fs.Report != nil {
+			s.fs.Report.Add(file.Change{Path: path, Operation: operation})
+		}
+	}
+	return nil
+}
+
+// defaultTemplates returns the templates scaffolded by the plugin which can be overridden.
+// The boilerplate is not included, as it is set by the --license and --owner flags.
+func defaultTemplates() []file.Template {
+	return []file.Template{
+		// Project
+		&templates.GitIgnore{},
+		&rbac.AuthProxyRole{},
+		&rbac.AuthProxyRoleBinding{},
+		&kdefault.AuthProxyPatch{},
+		&rbac.AuthProxyService{},
+		&rbac.ClientClusterRole{},
+		&manager.Config{},
+		&templates.Main{},
+		&templates.GoMod{},
+		&templates.Makefile{},
+		&templates.Dockerfile{},
+		&templates.DockerignoreFile{},
+		&kdefault.Kustomize{},
+		&kdefault.ManagerWebhookPatch{},
+		&rbac.ManagerRoleBinding{},
+		&rbac.LeaderElectionRole{},
+		&rbac.LeaderElectionRoleBinding{},
+		&rbac.KustomizeRBAC{},
+		&manager.Kustomization{},
+		&webhook.Kustomization{},
+		&webhook.KustomizeConfigWebhook{},
+		&webhook.Service{},
+		&prometheus.Kustomization{},
+		&prometheus.ServiceMonitor{},
+		&certmanager.CertManager{},
+		&certmanager.Kustomization{},
+		&certmanager.KustomizeConfig{},
+		// APIs
+		&api.Types{},
+		&api.Group{},
+		&samples.CRDSample{},
+		&rbac.CRDEditorRole{},
+		&rbac.CRDViewerRole{},
+		&crd.EnableWebhookPatch{},
+		&crd.EnableCAInjectionPatch{},
+		&crd.Kustomization{},
+		&crd.KustomizeConfig{},
+		// Controllers
+		&controller.SuiteTest{},
+		&controller.Controller{},
+		// Webhooks
+		&api.Webhook{},
+		&kdefault.InjectCAPatch{},
+	}
+}
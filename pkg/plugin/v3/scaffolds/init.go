@@ -17,12 +17,13 @@ limitations under the License.
 package scaffolds
 
 import (
-	"fmt"
-	"io/ioutil"
 	"path/filepath"
 
+	"github.com/spf13/afero"
+
 	"sigs.k8s.io/kubebuilder/pkg/model"
 	"sigs.k8s.io/kubebuilder/pkg/model/config"
+	"sigs.k8s.io/kubebuilder/pkg/model/file"
 	"sigs.k8s.io/kubebuilder/pkg/plugin/internal/machinery"
 	"sigs.k8s.io/kubebuilder/pkg/plugin/scaffold"
 	"sigs.k8s.io/kubebuilder/pkg/plugin/v3/scaffolds/internal/templates"
@@ -53,6 +54,9 @@ type initScaffolder struct {
 	boilerplatePath string
 	license         string
 	owner           string
+
+	// fs is the filesystem that will be used by the scaffolder
+	fs file.Filesystem
 }
 
 // NewInitScaffolder returns a new Scaffolder for project initialization operations
@@ -62,9 +66,15 @@ func NewInitScaffolder(config *config.Config, license, owner string) scaffold.Sc
 		boilerplatePath: filepath.Join("hack", "boilerplate.go.txt"),
 		license:         license,
 		owner:           owner,
+		fs:              file.NewOSFilesystem(),
 	}
 }
 
+// InjectFS implements Scaffolder
+func (s *initScaffolder) InjectFS(fs file.Filesystem) {
+	s.fs = fs
+}
+
 func (s *initScaffolder) newUniverse(boilerplate string) *model.Universe {
 	return model.NewUniverse(
 		model.WithConfig(s.config),
@@ -74,7 +84,6 @@ func (s *initScaffolder) newUniverse(boilerplate string) *model.Universe {
 
 // Scaffold implements Scaffolder
 func (s *initScaffolder) Scaffold() error {
-	fmt.Println("Writing scaffold for you to edit...")
 	return s.scaffold()
 }
 
@@ -84,20 +93,30 @@ func (s *initScaffolder) scaffold() error {
 	bpFile.Path = s.boilerplatePath
 	bpFile.License = s.license
 	bpFile.Owner = s.owner
-	if err := machinery.NewScaffold().Execute(
+	if _, err := machinery.NewScaffold(s.fs).Execute(
 		s.newUniverse(""),
 		bpFile,
 	); err != nil {
 		return err
 	}
 
-	boilerplate, err := ioutil.ReadFile(s.boilerplatePath) //nolint:gosec
+	boilerplate, err := afero.ReadFile(s.fs.FS, s.boilerplatePath)
 	if err != nil {
 		return err
 	}
 
-	return machinery.NewScaffold().Execute(
-		s.newUniverse(string(boilerplate)),
+	// The boilerplate is not part of the scaffold regenerated from the project, so it has no baseline
+	changes, err := s.scaffoldProject(string(boilerplate))
+	if err != nil {
+		return err
+	}
+	return updateBaseline(s.fs, changes, nil)
+}
+
+// scaffoldProject scaffolds the files of the project, which use the given boilerplate
+func (s *initScaffolder) scaffoldProject(boilerplate string) ([]file.Change, error) {
+	return machinery.NewScaffold(s.fs).Execute(
+		s.newUniverse(boilerplate),
 		&templates.GitIgnore{},
 		&rbac.AuthProxyRole{},
 		&rbac.AuthProxyRoleBinding{},
@@ -17,7 +17,6 @@ limitations under the License.
 package api
 
 import (
-	"fmt"
 	"path/filepath"
 	"strings"
 
@@ -56,16 +55,8 @@ func (f *Webhook) SetTemplateDefaults() error {
 		}
 	}
 	f.Path = f.Resource.Replacer().Replace(f.Path)
-	fmt.Println(f.Path)
 
-	webhookTemplate := webhookTemplate
-	if f.Defaulting {
-		webhookTemplate = webhookTemplate + defaultingWebhookTemplate
-	}
-	if f.Validating {
-		webhookTemplate = webhookTemplate + validatingWebhookTemplate
-	}
-	f.TemplateBody = webhookTemplate
+	f.TemplateBody = webhookTemplate + defaultingWebhookTemplate + validatingWebhookTemplate
 
 	f.IfExistsAction = file.Error
 
@@ -101,7 +92,7 @@ func (r *{{ .Resource.Kind }}) SetupWebhookWithManager(mgr ctrl.Manager) error {
 `
 
 	//nolint:lll
-	defaultingWebhookTemplate = `
+	defaultingWebhookTemplate = `{{ if .Defaulting }}
 // +kubebuilder:webhook:path=/mutate-{{ .GroupDomainWithDash }}-{{ .Resource.Version }}-{{ lower .Resource.Kind }},mutating=true,failurePolicy=fail,groups={{ .Resource.Domain }},resources={{ .Resource.Plural }},verbs=create;update,versions={{ .Resource.Version }},name=m{{ lower .Resource.Kind }}.kb.io
 
 var _ webhook.Defaulter = &{{ .Resource.Kind }}{}
@@ -112,9 +103,9 @@ func (r *{{ .Resource.Kind }}) Default() {
 
 	// TODO(user): fill in your defaulting logic.
 }
-`
+{{ end }}`
 	//nolint:lll
-	validatingWebhookTemplate = `
+	validatingWebhookTemplate = `{{ if .Validating }}
 // TODO(user): change verbs to "verbs=create;update;delete" if you want to enable deletion validation.
 // +kubebuilder:webhook:verbs=create;update,path=/validate-{{ .GroupDomainWithDash }}-{{ .Resource.Version }}-{{ lower .Resource.Kind }},mutating=false,failurePolicy=fail,groups={{ .Resource.Domain }},resources={{ .Resource.Plural }},versions={{ .Resource.Version }},name=v{{ lower .Resource.Kind }}.kb.io
 
@@ -143,5 +134,5 @@ func (r *{{ .Resource.Kind }}) ValidateDelete() error {
 	// TODO(user): fill in your validation logic upon object deletion.
 	return nil
 }
-`
+{{ end }}`
 )
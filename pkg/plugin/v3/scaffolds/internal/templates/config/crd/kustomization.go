@@ -24,7 +24,7 @@ import (
 )
 
 var _ file.Template = &Kustomization{}
-var _ file.Inserter = &Kustomization{}
+var _ file.YAMLInserter = &Kustomization{}
 
 // Kustomization scaffolds the kustomization file in manager folder.
 type Kustomization struct {
@@ -54,54 +54,29 @@ const (
 	caInjectionPatchMarker = "crdkustomizecainjectionpatch"
 )
 
-// GetMarkers implements file.Inserter
-func (f *Kustomization) GetMarkers() []file.Marker {
-	return []file.Marker{
-		file.NewMarkerFor(f.Path, resourceMarker),
-		file.NewMarkerFor(f.Path, webhookPatchMarker),
-		file.NewMarkerFor(f.Path, caInjectionPatchMarker),
+// GetEntries implements file.YAMLInserter
+func (f *Kustomization) GetEntries() []file.YAMLEntry {
+	return []file.YAMLEntry{
+		{
+			Key:    "resources",
+			Value:  fmt.Sprintf("bases/%s_%s.yaml", f.Resource.Domain, f.Resource.Plural),
+			Marker: file.NewMarkerFor(f.Path, resourceMarker),
+		},
+		{
+			Key:       "patchesStrategicMerge",
+			Value:     fmt.Sprintf("patches/webhook_in_%s.yaml", f.Resource.Plural),
+			Commented: true,
+			Marker:    file.NewMarkerFor(f.Path, webhookPatchMarker),
+		},
+		{
+			Key:       "patchesStrategicMerge",
+			Value:     fmt.Sprintf("patches/cainjection_in_%s.yaml", f.Resource.Plural),
+			Commented: true,
+			Marker:    file.NewMarkerFor(f.Path, caInjectionPatchMarker),
+		},
 	}
 }
 
-const (
-	resourceCodeFragment = `- bases/%s_%s.yaml
-`
-	webhookPatchCodeFragment = `#- patches/webhook_in_%s.yaml
-`
-	caInjectionPatchCodeFragment = `#- patches/cainjection_in_%s.yaml
-`
-)
-
-// GetCodeFragments implements file.Inserter
-func (f *Kustomization) GetCodeFragments() file.CodeFragmentsMap {
-	fragments := make(file.CodeFragmentsMap, 3)
-
-	// Generate resource code fragments
-	res := make([]string, 0)
-	res = append(res, fmt.Sprintf(resourceCodeFragment, f.Resource.Domain, f.Resource.Plural))
-
-	// Generate resource code fragments
-	webhookPatch := make([]string, 0)
-	webhookPatch = append(webhookPatch, fmt.Sprintf(webhookPatchCodeFragment, f.Resource.Plural))
-
-	// Generate resource code fragments
-	caInjectionPatch := make([]string, 0)
-	caInjectionPatch = append(caInjectionPatch, fmt.Sprintf(caInjectionPatchCodeFragment, f.Resource.Plural))
-
-	// Only store code fragments in the map if the slices are non-empty
-	if len(res) != 0 {
-		fragments[file.NewMarkerFor(f.Path, resourceMarker)] = res
-	}
-	if len(webhookPatch) != 0 {
-		fragments[file.NewMarkerFor(f.Path, webhookPatchMarker)] = webhookPatch
-	}
-	if len(caInjectionPatch) != 0 {
-		fragments[file.NewMarkerFor(f.Path, caInjectionPatchMarker)] = caInjectionPatch
-	}
-
-	return fragments
-}
-
 var kustomizationTemplate = `# This kustomization.yaml is not intended to be run by itself,
 # since it depends on service name and namespace that are out of this kustomize package.
 # It should be run by config/default
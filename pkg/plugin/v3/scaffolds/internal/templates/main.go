@@ -68,7 +68,7 @@ func (f *Main) GetFuncMap() template.FuncMap {
 	return fm
 }
 
-var _ file.Inserter = &MainUpdater{}
+var _ file.GoInserter = &MainUpdater{}
 
 // MainUpdater updates main.go to run Controllers
 type MainUpdater struct { //nolint:maligned
@@ -78,6 +78,10 @@ type MainUpdater struct { //nolint:maligned
 
 	// Flags to indicate which parts need to be included when updating the file
 	WireResource, WireController, WireWebhook bool
+
+	// Flags to indicate which shared parts are still used by other resources, so that they are
+	// not included when removing the parts of a deleted resource
+	APIInUse, ControllersInUse bool
 }
 
 // GetPath implements Builder
@@ -105,6 +109,15 @@ func (f *MainUpdater) GetMarkers() []file.Marker {
 	}
 }
 
+// GetInsertionPoints implements file.GoInserter
+func (f *MainUpdater) GetInsertionPoints() map[file.Marker]file.InsertionPoint {
+	return map[file.Marker]file.InsertionPoint{
+		file.NewMarkerFor(defaultMainPath, importMarker):    {Imports: true},
+		file.NewMarkerFor(defaultMainPath, addSchemeMarker): {Func: "init"},
+		file.NewMarkerFor(defaultMainPath, setupMarker):     {Func: "main", Before: `setupLog.Info("starting manager")`},
+	}
+}
+
 const (
 	apiImportCodeFragment = `%s "%s"
 `
@@ -150,8 +163,10 @@ func (f *MainUpdater) GetCodeFragments() file.CodeFragmentsMap {
 
 	// Generate import code fragments
 	imports := make([]string, 0)
-	imports = append(imports, fmt.Sprintf(apiImportCodeFragment, f.Resource.ImportAlias, f.Resource.Package))
-	if f.WireController {
+	if !f.APIInUse {
+		imports = append(imports, fmt.Sprintf(apiImportCodeFragment, f.Resource.ImportAlias, f.Resource.Package))
+	}
+	if f.WireController && !f.ControllersInUse {
 		if !f.MultiGroup || f.Resource.Group == "" {
 			imports = append(imports, fmt.Sprintf(controllerImportCodeFragment, f.Repo))
 		} else {
@@ -162,7 +177,9 @@ func (f *MainUpdater) GetCodeFragments() file.CodeFragmentsMap {
 
 	// Generate add scheme code fragments
 	addScheme := make([]string, 0)
-	addScheme = append(addScheme, fmt.Sprintf(addschemeCodeFragment, f.Resource.ImportAlias))
+	if !f.APIInUse {
+		addScheme = append(addScheme, fmt.Sprintf(addschemeCodeFragment, f.Resource.ImportAlias))
+	}
 
 	// Generate setup code fragments
 	setup := make([]string, 0)
@@ -0,0 +1,356 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaffolds
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/afero"
+
+	"sigs.k8s.io/kubebuilder/pkg/model"
+	"sigs.k8s.io/kubebuilder/pkg/model/config"
+	"sigs.k8s.io/kubebuilder/pkg/model/file"
+	"sigs.k8s.io/kubebuilder/pkg/model/resource"
+	"sigs.k8s.io/kubebuilder/pkg/plugin/internal/machinery"
+	"sigs.k8s.io/kubebuilder/pkg/plugin/scaffold"
+	"sigs.k8s.io/kubebuilder/pkg/plugin/v3/scaffolds/internal/templates"
+)
+
+var _ scaffold.Scaffolder = &migrateScaffolder{}
+
+// replacement replaces a fragment scaffolded by the version 2 templates with its version 3 counterpart
+type replacement struct {
+	old string
+	new string
+}
+
+type migrateScaffolder struct {
+	config *config.Config
+	layout string
+
+	// fs is the filesystem that will be used by the scaffolder
+	fs file.Filesystem
+}
+
+// NewMigrateScaffolder returns a new Scaffolder for migrating a version 2 project to the given layout
+func NewMigrateScaffolder(config *config.Config, layout string) scaffold.Scaffolder {
+	return &migrateScaffolder{
+		config: config,
+		layout: layout,
+		fs:     file.NewOSFilesystem(),
+	}
+}
+
+// InjectFS implements Scaffolder
+func (s *migrateScaffolder) InjectFS(fs file.Filesystem) {
+	s.fs = fs
+}
+
+// Scaffold implements Scaffolder
+func (s *migrateScaffolder) Scaffold() error {
+	// The resources are built before the version changes, as version 2 resolves them differently.
+	resources := make([]*resource.Resource, 0, len(s.config.Resources))
+	for _, gvk := range s.config.Resources {
+		opts := resource.Options{Group: gvk.Group, Version: gvk.Version, Kind: gvk.Kind}
+		resources = append(resources, opts.NewResource(s.config, true))
+	}
+
+	s.config.Version = config.Version3Alpha
+	s.config.Layout = config.Layout{s.layout}
+	for _, res := range resources {
+		metadata, err := s.resourceMetadata(res)
+		if err != nil {
+			return err
+		}
+		s.config.UpdateResource(metadata)
+	}
+
+	dockerignore := &templates.DockerignoreFile{}
+	dockerignore.IfExistsAction = file.Skip
+	if _, err := machinery.NewScaffold(s.fs).Execute(
+		model.NewUniverse(model.WithConfig(s.config)),
+		dockerignore,
+	); err != nil {
+		return err
+	}
+
+	if err := s.patchFiles(resources); err != nil {
+		return err
+	}
+	return s.checkMainMarkers()
+}
+
+// resourceMetadata returns the metadata tracked by version 3 for res, found in the sources of the project
+func (s *migrateScaffolder) resourceMetadata(res *resource.Resource) (config.GVK, error) {
+	metadata := res.Metadata()
+	metadata.Scope = config.NamespacedScope
+
+	typesPath := s.resourcePath(res, "types")
+	types, err := s.readFile(typesPath)
+	if err != nil {
+		return metadata, err
+	}
+	switch {
+	case types == "":
+		s.addManualStep(fmt.Sprintf("%s was not found, set the scope of %s in PROJECT", typesPath, res.Kind))
+	case strings.Contains(types, "+kubebuilder:resource:scope=Cluster"):
+		metadata.Scope = config.ClusterScope
+	}
+
+	controllerPath := filepath.Join("controllers", "%[kind]_controller.go")
+	if s.config.MultiGroup {
+		controllerPath = filepath.Join("controllers", "%[group]", "%[kind]_controller.go")
+	}
+	if _, err := s.fs.FS.Stat(res.Replacer().Replace(controllerPath)); err == nil {
+		metadata.Controller = true
+	} else if !os.IsNotExist(err) {
+		return metadata, err
+	}
+
+	webhook, err := s.readFile(s.resourcePath(res, "webhook"))
+	if err != nil {
+		return metadata, err
+	}
+	webhooks := config.Webhooks{
+		Defaulting: strings.Contains(webhook, "webhook.Defaulter"),
+		Validation: strings.Contains(webhook, "webhook.Validator"),
+		Conversion: strings.Contains(types+webhook, fmt.Sprintf("*%s) Hub()", res.Kind)) ||
+			strings.Contains(types+webhook, fmt.Sprintf("*%s) ConvertTo(", res.Kind)),
+	}
+	if webhooks != (config.Webhooks{}) {
+		metadata.Webhooks = &webhooks
+	}
+
+	return metadata, nil
+}
+
+// resourcePath returns the path of the file with the given suffix in the API package of res
+func (s *migrateScaffolder) resourcePath(res *resource.Resource, suffix string) string {
+	path := filepath.Join("api", "%[version]", "%[kind]_"+suffix+".go")
+	if s.config.MultiGroup {
+		path = filepath.Join("apis", "%[group]", "%[version]", "%[kind]_"+suffix+".go")
+	}
+	return res.Replacer().Replace(path)
+}
+
+// patchFiles replaces the fragments of the version 2 scaffold which differ in version 3
+func (s *migrateScaffolder) patchFiles(resources []*resource.Resource) error {
+	if err := s.patch(".gitignore", "ignore the envtest binaries with 'testbin/*'",
+		replacement{"*.dylib\nbin\n", "*.dylib\nbin\ntestbin/*\n"},
+	); err != nil {
+		return err
+	}
+
+	if err := s.patch("Dockerfile", "build with golang:1.15 and run as the non-root user 65532:65532",
+		replacement{"FROM golang:1.13 as builder", "FROM golang:1.15 as builder"},
+		replacement{"USER nonroot:nonroot", "USER 65532:65532"},
+	); err != nil {
+		return err
+	}
+
+	if err := s.patch("go.mod", "require go 1.15",
+		replacement{"\ngo 1.13\n", "\ngo 1.15\n"},
+	); err != nil {
+		return err
+	}
+
+	deploy := "\t$(KUSTOMIZE) build config/default | kubectl apply -f -\n"
+	if err := s.patch("Makefile", "set up envtest in the 'test' target and add an 'undeploy' target",
+		replacement{
+			"test: generate fmt vet manifests\n\tgo test ./... -coverprofile cover.out\n",
+			"ENVTEST_ASSETS_DIR=$(shell pwd)/testbin\n" +
+				"test: generate fmt vet manifests\n" +
+				"\tmkdir -p ${ENVTEST_ASSETS_DIR}\n" +
+				"\ttest -f ${ENVTEST_ASSETS_DIR}/setup-envtest.sh || curl -sSLo ${ENVTEST_ASSETS_DIR}/setup-envtest.sh " +
+				"https://raw.githubusercontent.com/kubernetes-sigs/controller-runtime/" + ControllerRuntimeVersion +
+				"/hack/setup-envtest.sh\n" +
+				"\tsource ${ENVTEST_ASSETS_DIR}/setup-envtest.sh; fetch_envtest_tools $(ENVTEST_ASSETS_DIR); " +
+				"setup_envtest_env $(ENVTEST_ASSETS_DIR); go test ./... -coverprofile cover.out\n",
+		},
+		replacement{
+			deploy,
+			deploy + "\n# UnDeploy controller from the configured Kubernetes cluster in ~/.kube/config\n" +
+				"undeploy:\n\t$(KUSTOMIZE) build config/default | kubectl delete -f -\n",
+		},
+	); err != nil {
+		return err
+	}
+
+	if err := s.patch(filepath.Join("config", "manager", "manager.yaml"),
+		"run the manager as the non-root user 65532 without privilege escalation",
+		replacement{
+			"    spec:\n      containers:\n",
+			"    spec:\n      securityContext:\n        runAsUser: 65532\n      containers:\n",
+		},
+		replacement{
+			"        name: manager\n        resources:\n",
+			"        name: manager\n        securityContext:\n          allowPrivilegeEscalation: false\n" +
+				"        resources:\n",
+		},
+	); err != nil {
+		return err
+	}
+
+	if err := s.patch(filepath.Join("config", "rbac", "leader_election_role.yaml"),
+		"remove the rule for configmaps/status, leader election does not use it",
+		replacement{
+			"  - configmaps/status\n  verbs:\n  - get\n  - update\n  - patch\n- apiGroups:\n  - \"\"\n  resources:\n",
+			"",
+		},
+	); err != nil {
+		return err
+	}
+
+	// The webhook patches of the CRDs are optional, only the existing ones are patched.
+	webhookPatches, err := afero.Glob(s.fs.FS, filepath.Join("config", "crd", "patches", "webhook_in_*.yaml"))
+	if err != nil {
+		return err
+	}
+	for _, path := range webhookPatches {
+		if err := s.patch(path, "remove the caBundle placeholder, it is set by cert-manager",
+			replacement{
+				"      # this is \"\\n\" used as a placeholder, otherwise it will be rejected by the apiserver for being blank,\n" +
+					"      # but we're going to set it later using the cert-manager " +
+					"(or potentially a patch if not using cert-manager)\n" +
+					"      caBundle: Cg==\n",
+				"",
+			},
+		); err != nil {
+			return err
+		}
+	}
+
+	suiteTests, err := afero.Glob(s.fs.FS, filepath.Join("controllers", "suite_test.go"))
+	if err != nil {
+		return err
+	}
+	groupSuiteTests, err := afero.Glob(s.fs.FS, filepath.Join("controllers", "*", "suite_test.go"))
+	if err != nil {
+		return err
+	}
+	for _, path := range append(suiteTests, groupSuiteTests...) {
+		if err := s.patch(path, "replace the deprecated zap.LoggerTo with zap.New",
+			replacement{
+				"logf.SetLogger(zap.LoggerTo(GinkgoWriter, true))",
+				"logf.SetLogger(zap.New(zap.WriteTo(GinkgoWriter), zap.UseDevMode(true)))",
+			},
+		); err != nil {
+			return err
+		}
+	}
+
+	for _, res := range resources {
+		if tracked, _ := s.config.GetResource(res.GVK()); !tracked.Controller {
+			continue
+		}
+		controllerPath := filepath.Join("controllers", "%[kind]_controller.go")
+		if s.config.MultiGroup {
+			controllerPath = filepath.Join("controllers", "%[group]", "%[kind]_controller.go")
+		}
+		status := fmt.Sprintf("// +kubebuilder:rbac:groups=%s,resources=%s/status,verbs=get;update;patch\n",
+			res.Domain, res.Plural)
+		finalizers := fmt.Sprintf("// +kubebuilder:rbac:groups=%s,resources=%s/finalizers,verbs=update\n",
+			res.Domain, res.Plural)
+		if err := s.patch(res.Replacer().Replace(controllerPath),
+			fmt.Sprintf("add the RBAC marker for the finalizers of %s", res.Plural),
+			replacement{status, status + finalizers},
+		); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// checkMainMarkers reports the scaffold markers of main.go which were removed, they are required
+// to wire the resources scaffolded after the migration
+func (s *migrateScaffolder) checkMainMarkers() error {
+	main, err := s.readFile("main.go")
+	if err != nil {
+		return err
+	}
+	if main == "" {
+		s.addManualStep("main.go was not found, restore it from the version 3 scaffold")
+		return nil
+	}
+	for _, marker := range []string{"imports", "scheme", "builder"} {
+		if !strings.Contains(main, "+kubebuilder:scaffold:"+marker) {
+			s.addManualStep(fmt.Sprintf("main.go: restore the '// +kubebuilder:scaffold:%s' marker", marker))
+		}
+	}
+	return nil
+}
+
+// patch applies the replacements to the file at path. Replacements which were already applied are ignored,
+// the file is reported as a manual step with description if it or any fragment to replace can not be found.
+func (s *migrateScaffolder) patch(path, description string, replacements ...replacement) error {
+	content, err := s.readFile(path)
+	if err != nil {
+		return err
+	}
+	if content == "" {
+		s.addManualStep(fmt.Sprintf("%s was not found, %s", path, description))
+		return nil
+	}
+
+	updated, missing := content, false
+	for _, r := range replacements {
+		if r.new != "" && strings.Contains(updated, r.new) {
+			continue
+		}
+		if !strings.Contains(updated, r.old) {
+			// Removed fragments may have been removed by the user already.
+			missing = missing || r.new != ""
+			continue
+		}
+		updated = strings.Replace(updated, r.old, r.new, 1)
+	}
+	if missing {
+		s.addManualStep(fmt.Sprintf("%s: %s", path, description))
+	}
+	if updated == content {
+		return nil
+	}
+
+	// false positive
+	// nolint:gosec
+	if err := afero.WriteFile(s.fs.FS, path, []byte(updated), 0644); err != nil {
+		return err
+	}
+	if s.fs.Report != nil {
+		s.fs.Report.Add(file.Change{Path: path, Operation: file.Overwritten})
+	}
+	return nil
+}
+
+// readFile returns the contents of the file at path, which are empty if it does not exist
+func (s *migrateScaffolder) readFile(path string) (string, error) {
+	b, err := afero.ReadFile(s.fs.FS, path)
+	if os.IsNotExist(err) {
+		return "", nil
+	}
+	return string(b), err
+}
+
+func (s *migrateScaffolder) addManualStep(step string) {
+	if s.fs.Report != nil {
+		s.fs.Report.AddManualStep(step)
+	}
+}
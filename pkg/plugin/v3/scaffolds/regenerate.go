@@ -0,0 +1,323 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaffolds
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/spf13/afero"
+
+	"sigs.k8s.io/kubebuilder/pkg/model/config"
+	"sigs.k8s.io/kubebuilder/pkg/model/file"
+	"sigs.k8s.io/kubebuilder/pkg/model/resource"
+	"sigs.k8s.io/kubebuilder/pkg/plugin/internal/machinery"
+	"sigs.k8s.io/kubebuilder/pkg/plugin/scaffold"
+)
+
+// BaselineDir is the directory of the project where the scaffold rendered by the last scaffolding command or
+// regeneration is stored, it is the base the next regeneration merges its changes from
+var BaselineDir = filepath.Join(".kubebuilder", "baseline")
+
+var _ scaffold.Scaffolder = &regenerateScaffolder{}
+
+type regenerateScaffolder struct {
+	config      *config.Config
+	boilerplate string
+
+	// fs is the filesystem that will be used by the scaffolder
+	fs file.Filesystem
+}
+
+// NewRegenerateScaffolder returns a new Scaffolder for re-rendering the scaffold of a project and its tracked
+// resources, and merging it into the project files
+func NewRegenerateScaffolder(config *config.Config, boilerplate string) scaffold.Scaffolder {
+	return &regenerateScaffolder{
+		config:      config,
+		boilerplate: boilerplate,
+		fs:          file.NewOSFilesystem(),
+	}
+}
+
+// InjectFS implements Scaffolder
+func (s *regenerateScaffolder) InjectFS(fs file.Filesystem) {
+	s.fs = fs
+}
+
+// Scaffold implements Scaffolder
+func (s *regenerateScaffolder) Scaffold() error {
+	pristine, paths, err := s.render()
+	if err != nil {
+		return err
+	}
+
+	for _, path := range paths {
+		if err := s.mergeFile(pristine, path); err != nil {
+			return err
+		}
+	}
+	return s.removeStaleBaselines(paths)
+}
+
+// updateBaseline applies the changes a scaffolder made to the project files to their baselines, so that the next
+// regeneration merges from the scaffold the files were created from. The created and overwritten files are their
+// own baseline, while the changes to the other files are applied by running replay against the baselines.
+// The baselines of the files which had none are not added, and the ones replay fails to update are removed so
+// that the next regeneration merges them without base. replay may be nil if there are no such changes.
+func updateBaseline(fs file.Filesystem, changes []file.Change, replay func(file.Filesystem) error) error {
+	s := &regenerateScaffolder{fs: fs}
+
+	var (
+		replayed  afero.Fs
+		replayErr error
+	)
+	for _, change := range changes {
+		baselinePath := filepath.Join(BaselineDir, change.Path)
+		_, hasBase, err := readFile(fs.FS, baselinePath)
+		if err != nil {
+			return err
+		}
+
+		switch change.Operation {
+		case file.Created, file.Overwritten:
+			content, _, err := readFile(fs.FS, change.Path)
+			if err != nil {
+				return err
+			}
+			if err := s.writeBaseline(change.Path, content); err != nil {
+				return err
+			}
+		case file.InsertedInto, file.RemovedFrom:
+			if !hasBase {
+				continue
+			}
+			if replayed == nil {
+				// The replay writes to an in-memory layer on top of the baselines
+				replayed = afero.NewCopyOnWriteFs(
+					afero.NewReadOnlyFs(afero.NewBasePathFs(fs.FS, BaselineDir)), afero.NewMemMapFs())
+				replayErr = replay(file.Filesystem{FS: replayed, Force: fs.Force, Templates: fs.Templates})
+			}
+			if replayErr == nil {
+				content, _, err := readFile(replayed, change.Path)
+				if err != nil {
+					return err
+				}
+				if err := s.writeBaseline(change.Path, content); err != nil {
+					return err
+				}
+				continue
+			}
+			fallthrough
+		case file.Deleted:
+			if !hasBase {
+				continue
+			}
+			if err := fs.FS.Remove(baselinePath); err != nil {
+				return err
+			}
+			s.record(baselinePath, file.Deleted)
+		}
+	}
+	return nil
+}
+
+// copyConfig returns a copy of config whose resources can be updated without updating the ones of config
+func copyConfig(c *config.Config) *config.Config {
+	cfg := *c
+	cfg.Resources = append([]config.GVK(nil), c.Resources...)
+	return &cfg
+}
+
+// render scaffolds the project and its tracked resources from scratch into an in-memory filesystem,
+// and returns it along with the sorted paths of the scaffolded files
+func (s *regenerateScaffolder) render() (afero.Fs, []string, error) {
+	fs := file.Filesystem{FS: afero.NewMemMapFs(), Templates: s.fs.Templates}
+
+	// The resources are tracked again as they are scaffolded, in the same order
+	cfg := *s.config
+	cfg.Resources = nil
+
+	init := &initScaffolder{config: &cfg, boilerplatePath: filepath.Join("hack", "boilerplate.go.txt"), fs: fs}
+	if _, err := init.scaffoldProject(s.boilerplate); err != nil {
+		return nil, nil, fmt.Errorf("error scaffolding the project: %w", err)
+	}
+
+	for _, gvk := range s.config.Resources {
+		opts := resource.Options{
+			Group:      gvk.Group,
+			Version:    gvk.Version,
+			Kind:       gvk.Kind,
+			Plural:     gvk.Plural,
+			Namespaced: gvk.Scope != config.ClusterScope,
+		}
+		res := opts.NewResource(&cfg, true)
+
+		api := &apiScaffolder{config: &cfg, boilerplate: s.boilerplate, resource: res,
+			doResource: true, doController: gvk.Controller, fs: fs}
+		if _, err := api.scaffold(); err != nil {
+			return nil, nil, fmt.Errorf("error scaffolding %s: %w", gvk.Kind, err)
+		}
+
+		if gvk.Webhooks != nil {
+			webhook := &webhookScaffolder{config: &cfg, boilerplate: s.boilerplate, resource: res,
+				defaulting: gvk.Webhooks.Defaulting, validation: gvk.Webhooks.Validation,
+				conversion: gvk.Webhooks.Conversion, fs: fs}
+			if _, err := webhook.scaffold(); err != nil {
+				return nil, nil, fmt.Errorf("error scaffolding the webhooks of %s: %w", gvk.Kind, err)
+			}
+		}
+	}
+
+	var paths []string
+	if err := afero.Walk(fs.FS, "", func(path string, info os.FileInfo, err error) error {
+		if err == nil && !info.IsDir() {
+			paths = append(paths, path)
+		}
+		return err
+	}); err != nil {
+		return nil, nil, err
+	}
+	sort.Strings(paths)
+
+	return fs.FS, paths, nil
+}
+
+// mergeFile merges the pristine scaffold of path into the project file, and stores it as its new baseline.
+// Files removed from the project since the last regeneration are not scaffolded again.
+func (s *regenerateScaffolder) mergeFile(pristine afero.Fs, path string) error {
+	scaffolded, _, err := readFile(pristine, path)
+	if err != nil {
+		return err
+	}
+	current, exists, err := readFile(s.fs.FS, path)
+	if err != nil {
+		return err
+	}
+	baselinePath := filepath.Join(BaselineDir, path)
+	base, hasBase, err := readFile(s.fs.FS, baselinePath)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case !exists && !hasBase:
+		if err := s.writeFile(path, scaffolded, file.Created); err != nil {
+			return err
+		}
+	case exists:
+		var (
+			merged    string
+			conflicts int
+		)
+		if hasBase {
+			merged, conflicts = machinery.Merge(base, current, scaffolded)
+		} else {
+			merged, conflicts = machinery.MergeWithoutBase(current, scaffolded)
+		}
+		if merged != current {
+			if err := s.writeFile(path, merged, file.Merged); err != nil {
+				return err
+			}
+		}
+		if conflicts != 0 && s.fs.Report != nil {
+			s.fs.Report.AddManualStep(fmt.Sprintf("%s: resolve the %d conflict(s) between your changes and the "+
+				"new scaffold, marked with <<<<<<< and >>>>>>>", path, conflicts))
+		}
+	}
+
+	return s.writeBaseline(path, scaffolded)
+}
+
+// writeBaseline stores scaffolded as the baseline of path, unless it already is
+func (s *regenerateScaffolder) writeBaseline(path, scaffolded string) error {
+	baselinePath := filepath.Join(BaselineDir, path)
+	base, hasBase, err := readFile(s.fs.FS, baselinePath)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case !hasBase:
+		return s.writeFile(baselinePath, scaffolded, file.Created)
+	case base != scaffolded:
+		return s.writeFile(baselinePath, scaffolded, file.Overwritten)
+	default:
+		return nil
+	}
+}
+
+// removeStaleBaselines removes the baselines of the files which are no longer scaffolded
+func (s *regenerateScaffolder) removeStaleBaselines(paths []string) error {
+	scaffolded := make(map[string]bool, len(paths))
+	for _, path := range paths {
+		scaffolded[filepath.Join(BaselineDir, path)] = true
+	}
+
+	var stale []string
+	if err := afero.Walk(s.fs.FS, BaselineDir, func(path string, info os.FileInfo, err error) error {
+		switch {
+		case os.IsNotExist(err):
+			return filepath.SkipDir
+		case err != nil:
+			return err
+		case !info.IsDir() && !scaffolded[path]:
+			stale = append(stale, path)
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	for _, path := range stale {
+		if err := s.fs.FS.Remove(path); err != nil {
+			return err
+		}
+		s.record(path, file.Deleted)
+	}
+	return nil
+}
+
+// writeFile writes content to the file at path and records the operation
+func (s *regenerateScaffolder) writeFile(path, content string, operation file.Operation) error {
+	if err := s.fs.FS.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	// false positive
+	// nolint:gosec
+	if err := afero.WriteFile(s.fs.FS, path, []byte(content), 0644); err != nil {
+		return err
+	}
+	s.record(path, operation)
+	return nil
+}
+
+func (s *regenerateScaffolder) record(path string, operation file.Operation) {
+	if s.fs.Report != nil {
+		s.fs.Report.Add(file.Change{Path: path, Operation: operation})
+	}
+}
+
+// readFile returns the contents of the file at path in fs and whether it exists
+func readFile(fs afero.Fs, path string) (string, bool, error) {
+	b, err := afero.ReadFile(fs, path)
+	if os.IsNotExist(err) {
+		return "", false, nil
+	}
+	return string(b), err == nil, err
+}
@@ -17,10 +17,9 @@ limitations under the License.
 package scaffolds
 
 import (
-	"fmt"
-
 	"sigs.k8s.io/kubebuilder/pkg/model"
 	"sigs.k8s.io/kubebuilder/pkg/model/config"
+	"sigs.k8s.io/kubebuilder/pkg/model/file"
 	"sigs.k8s.io/kubebuilder/pkg/model/resource"
 	"sigs.k8s.io/kubebuilder/pkg/plugin/internal/machinery"
 	"sigs.k8s.io/kubebuilder/pkg/plugin/scaffold"
@@ -29,6 +28,10 @@ import (
 	"sigs.k8s.io/kubebuilder/pkg/plugin/v3/scaffolds/internal/templates/config/kdefault"
 )
 
+// conversionStep is the manual step left after scaffolding a conversion webhook
+const conversionStep = "implement the conversion.Hub and conversion.Convertible interfaces for your CRD types, " +
+	"the webhook server has been set up for you"
+
 var _ scaffold.Scaffolder = &webhookScaffolder{}
 
 type webhookScaffolder struct {
@@ -38,6 +41,9 @@ type webhookScaffolder struct {
 
 	// Webhook type options.
 	defaulting, validation, conversion bool
+
+	// fs is the filesystem that will be used by the scaffolder
+	fs file.Filesystem
 }
 
 // NewWebhookScaffolder returns a new Scaffolder for v2 webhook creation operations
@@ -56,13 +62,32 @@ func NewWebhookScaffolder(
 		defaulting:  defaulting,
 		validation:  validation,
 		conversion:  conversion,
+		fs:          file.NewOSFilesystem(),
 	}
 }
 
+// InjectFS implements Scaffolder
+func (s *webhookScaffolder) InjectFS(fs file.Filesystem) {
+	s.fs = fs
+}
+
 // Scaffold implements Scaffolder
 func (s *webhookScaffolder) Scaffold() error {
-	fmt.Println("Writing scaffold for you to edit...")
-	return s.scaffold()
+	replay := *s
+	replay.config = copyConfig(s.config)
+
+	changes, err := s.scaffold()
+	if err != nil {
+		return err
+	}
+	if s.conversion && s.fs.Report != nil {
+		s.fs.Report.AddManualStep(conversionStep)
+	}
+	return updateBaseline(s.fs, changes, func(fs file.Filesystem) error {
+		replay.fs = fs
+		_, err := replay.scaffold()
+		return err
+	})
 }
 
 func (s *webhookScaffolder) newUniverse() *model.Universe {
@@ -73,20 +98,17 @@ func (s *webhookScaffolder) newUniverse() *model.Universe {
 	)
 }
 
-func (s *webhookScaffolder) scaffold() error {
-	if s.conversion {
-		fmt.Println(`Webhook server has been set up for you.
-You need to implement the conversion.Hub and conversion.Convertible interfaces for your CRD types.`)
-	}
+func (s *webhookScaffolder) scaffold() ([]file.Change, error) {
+	// The webhooks of a resource are only wired once
+	tracked, _ := s.config.GetResource(s.resource.GVK())
+	gvk := s.resource.GVK()
+	gvk.Webhooks = &config.Webhooks{Defaulting: s.defaulting, Validation: s.validation, Conversion: s.conversion}
+	s.config.UpdateResource(gvk)
 
-	if err := machinery.NewScaffold().Execute(
+	return machinery.NewScaffold(s.fs).Execute(
 		s.newUniverse(),
 		&api.Webhook{Defaulting: s.defaulting, Validating: s.validation},
-		&templates.MainUpdater{WireWebhook: true},
+		&templates.MainUpdater{WireWebhook: tracked.Webhooks == nil},
 		&kdefault.InjectCAPatch{},
-	); err != nil {
-		return err
-	}
-
-	return nil
+	)
 }
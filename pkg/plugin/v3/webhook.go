@@ -18,13 +18,15 @@ package v3
 
 import (
 	"fmt"
-	"io/ioutil"
 	"path/filepath"
+	"strings"
 
+	"github.com/spf13/afero"
 	"github.com/spf13/pflag"
 
 	"sigs.k8s.io/kubebuilder/internal/cmdutil"
 	"sigs.k8s.io/kubebuilder/pkg/model/config"
+	"sigs.k8s.io/kubebuilder/pkg/model/file"
 	"sigs.k8s.io/kubebuilder/pkg/model/resource"
 	"sigs.k8s.io/kubebuilder/pkg/plugin"
 	"sigs.k8s.io/kubebuilder/pkg/plugin/scaffold"
@@ -33,6 +35,8 @@ import (
 
 type createWebhookPlugin struct {
 	config *config.Config
+	// ctx is the runtime context provided by the CLI.
+	ctx plugin.Context
 	// For help text.
 	commandName string
 
@@ -40,11 +44,18 @@ type createWebhookPlugin struct {
 	defaulting bool
 	validation bool
 	conversion bool
+
+	// force are the values of the --force flag
+	force []string
+	// forcedClasses are the classes of files overwritten if they already exist, the webhooks are created
+	// even if they already exist if there is any
+	forcedClasses []file.Class
 }
 
 var (
 	_ plugin.CreateWebhook = &createWebhookPlugin{}
-	_ cmdutil.RunOptions   = &createAPIPlugin{}
+	_ cmdutil.RunOptions   = &createWebhookPlugin{}
+	_ cmdutil.HasForce     = &createWebhookPlugin{}
 )
 
 func (p *createWebhookPlugin) UpdateContext(ctx *plugin.Context) {
@@ -61,6 +72,7 @@ validating and (or) conversion webhooks.
 		ctx.CommandName, ctx.CommandName)
 
 	p.commandName = ctx.CommandName
+	p.ctx = *ctx
 }
 
 func (p *createWebhookPlugin) BindFlags(fs *pflag.FlagSet) {
@@ -76,6 +88,8 @@ func (p *createWebhookPlugin) BindFlags(fs *pflag.FlagSet) {
 		"if set, scaffold the validating webhook")
 	fs.BoolVar(&p.conversion, "conversion", false,
 		"if set, scaffold the conversion webhook")
+
+	bindForceFlag(fs, &p.force, "attempt to create the webhooks even if they already exist")
 }
 
 func (p *createWebhookPlugin) InjectConfig(c *config.Config) {
@@ -83,7 +97,7 @@ func (p *createWebhookPlugin) InjectConfig(c *config.Config) {
 }
 
 func (p *createWebhookPlugin) Run() error {
-	return cmdutil.Run(p)
+	return cmdutil.Run(p, p.ctx)
 }
 
 func (p *createWebhookPlugin) Validate() error {
@@ -91,32 +105,63 @@ func (p *createWebhookPlugin) Validate() error {
 		return err
 	}
 
+	var err error
+	if p.forcedClasses, err = parseForce(p.force); err != nil {
+		return err
+	}
+
 	if !p.defaulting && !p.validation && !p.conversion {
 		return fmt.Errorf("%s create webhook requires at least one of --defaulting,"+
 			" --programmatic-validation and --conversion to be true", p.commandName)
 	}
 
 	// check if resource exist to create webhook
-	if !p.config.HasResource(p.resource.GVK()) {
+	res, found := p.config.GetResource(p.resource.GVK())
+	if !found {
 		return fmt.Errorf("%s create webhook requires an api with the group,"+
 			" kind and version provided", p.commandName)
 	}
 
+	// check that the requested webhooks were not scaffolded yet, which is only tracked in v3+ projects
+	if res.Webhooks != nil && len(p.forcedClasses) == 0 {
+		var duplicates []string
+		if p.defaulting && res.Webhooks.Defaulting {
+			duplicates = append(duplicates, "defaulting")
+		}
+		if p.validation && res.Webhooks.Validation {
+			duplicates = append(duplicates, "validation")
+		}
+		if p.conversion && res.Webhooks.Conversion {
+			duplicates = append(duplicates, "conversion")
+		}
+		if len(duplicates) != 0 {
+			return fmt.Errorf("%s webhooks already exist for %s/%s, kind %s", strings.Join(duplicates, " and "),
+				res.Group, res.Version, res.Kind)
+		}
+	}
+
 	return nil
 }
 
 func (p *createWebhookPlugin) GetScaffolder() (scaffold.Scaffolder, error) {
 	// Load the boilerplate
-	bp, err := ioutil.ReadFile(filepath.Join("hack", "boilerplate.go.txt")) // nolint:gosec
+	bp, err := afero.ReadFile(p.ctx.Filesystem, filepath.Join("hack", "boilerplate.go.txt"))
 	if err != nil {
 		return nil, fmt.Errorf("unable to load boilerplate: %v", err)
 	}
 
 	// Create the actual resource from the resource options
 	res := p.resource.NewResource(p.config, false)
+
+	fmt.Fprintln(p.ctx.Stdout, "Writing scaffold for you to edit...")
 	return scaffolds.NewWebhookScaffolder(p.config, string(bp), res, p.defaulting, p.validation, p.conversion), nil
 }
 
+// GetForce implements cmdutil.HasForce
+func (p *createWebhookPlugin) GetForce() []file.Class {
+	return p.forcedClasses
+}
+
 func (p *createWebhookPlugin) PostScaffold() error {
 	return nil
 }
@@ -0,0 +1,52 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// schema prints the JSON Schema of the project config published in docs/project.schema.json.
+// Unlike "kubebuilder alpha config schema", only the built-in plugins are registered and neither the external
+// plugins nor the user defaults of the machine are loaded, so the schema is the same wherever it is generated.
+package main
+
+import (
+	"os"
+
+	"github.com/spf13/afero"
+
+	"sigs.k8s.io/kubebuilder/pkg/cli"
+	pluginv2 "sigs.k8s.io/kubebuilder/pkg/plugin/v2"
+	pluginv3 "sigs.k8s.io/kubebuilder/pkg/plugin/v3"
+)
+
+func main() {
+	c, err := cli.New(
+		cli.WithPlugins(
+			&pluginv2.Plugin{},
+			&pluginv3.Plugin{},
+		),
+		cli.WithDefaultPlugins(
+			&pluginv2.Plugin{},
+		),
+		// No project is read from the working directory
+		cli.WithFilesystem(afero.NewMemMapFs()),
+		cli.WithArgs("alpha", "config", "schema"),
+	)
+	if err != nil {
+		cli.PrintError(os.Stderr, err)
+		os.Exit(cli.ExitCode(err))
+	}
+	if err := c.Run(); err != nil {
+		os.Exit(cli.ExitCode(err))
+	}
+}